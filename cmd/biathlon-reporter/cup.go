@@ -0,0 +1,74 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/config"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/cup"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/report"
+)
+
+var errNoRaceReports = errors.New("no race reports, provide paths to reports in json format as arguments")
+
+// cupCommand calculates season cup standings from several race reports.
+func cupCommand(args []string) error {
+	flags := flag.NewFlagSet("cup", flag.ExitOnError)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: %s cup [options] race-report.json...\n", os.Args[0])
+		flags.PrintDefaults()
+	}
+
+	configFilePathFlag := flags.String("config", "", "Path to cup configuration file")
+	standingsFormatFlag := flags.String("format", string(report.FormatText), "Format of standings: text or json")
+
+	_ = flags.Parse(args)
+
+	if *configFilePathFlag == "" {
+		return errNoConfigFile
+	}
+
+	if flags.NArg() == 0 {
+		return errNoRaceReports
+	}
+
+	conf := config.Cup{}
+	err := config.Read(*configFilePathFlag, &conf)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+
+	format, err := report.ParseFormat(*standingsFormatFlag)
+	if err != nil {
+		return err
+	}
+
+	races := make([]cup.Race, 0, flags.NArg())
+	for _, raceReportPath := range flags.Args() {
+		race, err := readRace(raceReportPath, conf.Category)
+		if err != nil {
+			return err
+		}
+
+		races = append(races, race)
+	}
+
+	err = cup.Calculate(conf, races).Write(os.Stdout, format)
+	if err != nil {
+		return fmt.Errorf("write standings: %w", err)
+	}
+
+	return nil
+}
+
+func readRace(path string, category string) (cup.Race, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return cup.Race{}, fmt.Errorf("open race report: %w", err)
+	}
+	defer file.Close()
+
+	return cup.ReadRace(path, file, category)
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/config"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event/parser"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/manager"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/report"
+)
+
+var errNoCompetitions = errors.New("no competitions, provide them with --competition key=config option")
+
+// keyValues is a repeated flag with values like key=value or value.
+type keyValues []string
+
+func (v *keyValues) String() string {
+	return strings.Join(*v, ",")
+}
+
+func (v *keyValues) Set(s string) error {
+	*v = append(*v, s)
+
+	return nil
+}
+
+// keyedLogger logs events of competition prefixed with its key.
+type keyedLogger struct {
+	key    string
+	writer io.Writer
+}
+
+func (l keyedLogger) NotifyWithEvent(e event.Event) {
+	_ = l.TryNotifyWithEvent(e)
+}
+
+func (l keyedLogger) TryNotifyWithEvent(e event.Event) error {
+	_, err := fmt.Fprintln(l.writer, l.key, e)
+
+	return err
+}
+
+var reportExtensions = map[report.Format]string{
+	report.FormatText: ".txt",
+	report.FormatJSON: ".json",
+}
+
+// multiCommand handles events of several simultaneous competitions and makes report for each of them.
+func multiCommand(args []string) error {
+	flags := flag.NewFlagSet("multi", flag.ExitOnError)
+
+	var competitionFlags, eventsFlags keyValues
+
+	flags.Var(&competitionFlags, "competition", "Competition key and path to its config: key=config.json, can be repeated")
+	flags.Var(&eventsFlags, "events",
+		"Path to events file of single competition: key=events, or path to events file with lines prefixed "+
+			"with competition key, can be repeated")
+	reportDirFlag := flags.String("report-dir", ".", "Directory to save reports, report of each competition is named by its key")
+	reportFormatFlag := flags.String("format", string(report.FormatText), "Format of reports: text or json")
+
+	_ = flags.Parse(args)
+
+	if len(competitionFlags) == 0 {
+		return errNoCompetitions
+	}
+
+	if len(eventsFlags) == 0 {
+		return errNoEventsFile
+	}
+
+	format, err := report.ParseFormat(*reportFormatFlag)
+	if err != nil {
+		return err
+	}
+
+	competitions := manager.New()
+	defer competitions.Close()
+
+	configs := make(map[string]config.BiathlonCompetition, len(competitionFlags))
+
+	for _, value := range competitionFlags {
+		key, configFilePath, found := strings.Cut(value, "=")
+		if !found {
+			return fmt.Errorf("bad competition '%s', expected key=config", value)
+		}
+
+		conf := config.BiathlonCompetition{}
+
+		err = config.Read(configFilePath, &conf)
+		if err != nil {
+			return fmt.Errorf("read config of competition '%s': %w", key, err)
+		}
+
+		err = competitions.Add(key, conf, keyedLogger{key: key, writer: os.Stdout})
+		if err != nil {
+			return err
+		}
+
+		configs[key] = conf
+	}
+
+	// events files are handled concurrently, as events of different competitions are independent.
+	errs := make([]error, len(eventsFlags))
+	wg := sync.WaitGroup{}
+
+	for i, value := range eventsFlags {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			errs[i] = handleEventsFile(competitions, value)
+		}()
+	}
+
+	wg.Wait()
+
+	err = errors.Join(errs...)
+	if err != nil {
+		return err
+	}
+
+	for _, key := range competitions.Keys() {
+		err = writeCompetitionReport(competitions, key, configs[key], *reportDirFlag, format)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// handleEventsFile handles events file given as key=path or path to file with lines prefixed with competition key.
+func handleEventsFile(competitions *manager.Manager, value string) error {
+	key, path, single := strings.Cut(value, "=")
+	if !single {
+		path = value
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open events file: %w", err)
+	}
+	defer file.Close()
+
+	lines, retErrFunc := parser.Lines(file)
+	for line := range lines {
+		if single {
+			var e event.Event
+
+			e, err = parser.ParseSingleLine(line)
+			if err == nil {
+				err = competitions.HandleEvent(key, e)
+			}
+		} else {
+			err = competitions.HandleLine(line)
+		}
+
+		if err != nil {
+			return fmt.Errorf("handling events file '%s': %w", path, err)
+		}
+	}
+
+	err = retErrFunc()
+	if err != nil {
+		return fmt.Errorf("reading file '%s': %w", path, err)
+	}
+
+	return nil
+}
+
+func writeCompetitionReport(
+	competitions *manager.Manager,
+	key string,
+	conf config.BiathlonCompetition,
+	reportDir string,
+	format report.Format,
+) error {
+	res, err := competitions.Report(key)
+	if err != nil {
+		return err
+	}
+
+	reportFilePath := filepath.Join(reportDir, key+reportExtensions[format])
+
+	reportFile, err := os.OpenFile(reportFilePath, os.O_CREATE|os.O_TRUNC|os.O_RDWR, 0o666)
+	if err != nil {
+		return fmt.Errorf("open file for report: '%s': %w", reportFilePath, err)
+	}
+	defer reportFile.Close()
+
+	err = report.NewDocument(res, conf).Write(reportFile, format)
+	if err != nil {
+		return fmt.Errorf("write report of competition '%s': %w", key, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,166 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"iter"
+	"os"
+	"time"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/competition"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/config"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event/parser"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/journal"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/report"
+)
+
+var errReplaySource = errors.New("provide either events file with --events option or journal with --journal option")
+
+// replayCommand handles incoming events up to the given moment and makes provisional report of this moment.
+func replayCommand(args []string) error {
+	flags := flag.NewFlagSet("replay", flag.ExitOnError)
+
+	configFilePathFlag := flags.String("config", "", "Path to configuration file")
+	incomingEventsFilePath := flags.String("events", "", "Path to events file")
+	journalFilePathFlag := flags.String("journal", "", "Path to journal file, used instead of events file")
+	untilFlag := flags.String("until", "", "Handle events up to this time (inclusive) in format HH:MM:SS[.sss]")
+	indexFlag := flags.Int("index", 0, "Handle only this amount of incoming events, 0 to handle all")
+	reportFilePathFlag := flags.String("report", "", "Path of file to save report. Default is stdout")
+	reportFormatFlag := flags.String("format", string(report.FormatText), "Format of report: text or json")
+
+	_ = flags.Parse(args)
+
+	if *configFilePathFlag == "" {
+		return errNoConfigFile
+	}
+
+	if (*incomingEventsFilePath == "") == (*journalFilePathFlag == "") {
+		return errReplaySource
+	}
+
+	conf := config.BiathlonCompetition{}
+	err := config.Read(*configFilePathFlag, &conf)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+
+	format, err := report.ParseFormat(*reportFormatFlag)
+	if err != nil {
+		return err
+	}
+
+	var until time.Time
+	if *untilFlag != "" {
+		until, err = time.Parse(time.TimeOnly, *untilFlag)
+		if err != nil {
+			return fmt.Errorf("bad time in --until option: %w", err)
+		}
+	}
+
+	sourcePath := *incomingEventsFilePath
+	if sourcePath == "" {
+		sourcePath = *journalFilePathFlag
+	}
+
+	file, err := os.Open(sourcePath)
+	if err != nil {
+		return fmt.Errorf("open events source: %w", err)
+	}
+	defer file.Close()
+
+	events, retErrFunc := replayEvents(file, *journalFilePathFlag != "")
+
+	reporter, err := replay(conf, events, replayCutOff{index: *indexFlag, until: until})
+	if err != nil {
+		return err
+	}
+
+	err = retErrFunc()
+	if err != nil {
+		return fmt.Errorf("reading file: %w", err)
+	}
+
+	var w io.Writer = os.Stdout
+	if *reportFilePathFlag != "" {
+		var reportFile *os.File
+
+		reportFile, err = os.OpenFile(*reportFilePathFlag, os.O_CREATE|os.O_TRUNC|os.O_RDWR, 0o666)
+		if err != nil {
+			return fmt.Errorf("open file for report: '%s': %w", *reportFilePathFlag, err)
+		}
+		defer reportFile.Close()
+
+		w = reportFile
+	}
+
+	err = report.NewDocument(reporter.MakeProvisionalReport(), conf).Write(w, format)
+	if err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+
+	return nil
+}
+
+// replayEvents returns incoming events from events file or journal.
+func replayEvents(r io.Reader, fromJournal bool) (iter.Seq2[event.Event, error], func() error) {
+	if fromJournal {
+		return journal.IncomingEvents(journal.Records(r)), func() error { return nil }
+	}
+
+	lines, retErrFunc := parser.Lines(r)
+
+	return parser.ParsedLines(lines), retErrFunc
+}
+
+// replayCutOff is the moment of the race, up to which events are replayed.
+type replayCutOff struct {
+	// index - amount of events to handle, 0 to handle all.
+	index int
+	// until - events after this time are not handled, zero to handle all.
+	until time.Time
+}
+
+// reached returns true if incoming event is after cut-off, handled - amount of already handled events.
+func (c replayCutOff) reached(handled int, incomingEvent event.Event) bool {
+	return (c.index > 0 && handled == c.index) || (!c.until.IsZero() && incomingEvent.Time.After(c.until))
+}
+
+// replay handles incoming events up to cut-off and returns reporter, which observed them.
+func replay(
+	conf config.BiathlonCompetition,
+	events iter.Seq2[event.Event, error],
+	cutOff replayCutOff,
+) (*report.Reporter, error) {
+	reporter := report.NewReporter(conf)
+	observer := competition.NewComposedObserver().AddObservers(reporter)
+
+	biathlon, err := competition.NewBiathlon(conf, observer)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create biathlon competition: %w", err)
+	}
+
+	handler := eventsHandler{targetsChecker: parser.NewTargetsChecker(conf), biathlon: biathlon, observer: observer}
+	handled := 0
+
+	for incomingEvent, err := range events {
+		if err != nil {
+			return nil, fmt.Errorf("reading events: %w", err)
+		}
+
+		if cutOff.reached(handled, incomingEvent) {
+			break
+		}
+
+		err = handler.handle(incomingEvent)
+		if err != nil {
+			return nil, err
+		}
+
+		handled += 1
+	}
+
+	return reporter, nil
+}
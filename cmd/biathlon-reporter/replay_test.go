@@ -0,0 +1,115 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/config"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event/parser"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/journal"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/report"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	replayConfigPath = "../../testdata/from_task_readme/config.json"
+	replayEventsPath = "../../testdata/from_task_readme/events"
+)
+
+func replayResult(t *testing.T, path string, fromJournal bool, cutOff replayCutOff) report.JSONResult {
+	conf := config.BiathlonCompetition{}
+	assert.Nil(t, config.Read(replayConfigPath, &conf))
+
+	file, err := os.Open(path)
+	assert.Nil(t, err)
+	defer file.Close()
+
+	events, retErrFunc := replayEvents(file, fromJournal)
+
+	reporter, err := replay(conf, events, cutOff)
+	assert.Nil(t, err)
+	assert.Nil(t, retErrFunc())
+
+	buf := bytes.Buffer{}
+	assert.Nil(t, report.NewDocument(reporter.MakeProvisionalReport(), conf).Write(&buf, report.FormatJSON))
+
+	document := report.JSONDocument{}
+	assert.Nil(t, json.Unmarshal(buf.Bytes(), &document))
+	assert.Len(t, document.Results, 1)
+
+	return document.Results[0]
+}
+
+func Test_replay(t *testing.T) {
+	t.Run("without cut-off", func(t *testing.T) {
+		result := replayResult(t, replayEventsPath, false, replayCutOff{})
+
+		assert.Equal(t, report.StatusDNF, result.Status)
+		assert.Nil(t, result.Checkpoint)
+	})
+
+	t.Run("with index", func(t *testing.T) {
+		result := replayResult(t, replayEventsPath, false, replayCutOff{index: 5})
+
+		assert.Equal(t, report.StatusRunning, result.Status)
+		assert.Equal(t, &report.JSONCheckpoint{Time: "09:49:31.659", Event: "arrived at firing range"}, result.Checkpoint)
+		assert.Equal(t, "at range 1", result.Location)
+	})
+
+	t.Run("with until", func(t *testing.T) {
+		until, err := parser.ParseTime("09:49:55.915")
+		assert.Nil(t, err)
+
+		result := replayResult(t, replayEventsPath, false, replayCutOff{until: until})
+
+		assert.Equal(t, report.StatusRunning, result.Status)
+		assert.Equal(t, &report.JSONCheckpoint{Time: "09:49:55.915", Event: "entered penalty laps"}, result.Checkpoint)
+		assert.Equal(t, uint32(4), result.Hits)
+	})
+
+	t.Run("with index before until", func(t *testing.T) {
+		until, err := parser.ParseTime("09:49:55.915")
+		assert.Nil(t, err)
+
+		result := replayResult(t, replayEventsPath, false, replayCutOff{index: 4, until: until})
+
+		assert.Equal(t, report.StatusRunning, result.Status)
+		assert.Equal(t, &report.JSONCheckpoint{Time: "09:30:01.005", Event: "started"}, result.Checkpoint)
+	})
+
+	t.Run("from journal", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "journal")
+
+		eventsJournal, err := journal.Open(path)
+		assert.Nil(t, err)
+
+		file, err := os.Open(replayEventsPath)
+		assert.Nil(t, err)
+		defer file.Close()
+
+		lines, retErrFunc := parser.Lines(file)
+		for e, err := range parser.ParsedLines(lines) {
+			assert.Nil(t, err)
+
+			eventsJournal.NotifyWithEvent(e)
+			// outgoing events must be skipped on replay.
+			eventsJournal.NotifyWithEvent(event.Event{Time: e.Time, ID: event.CompetitorDisqualified, CompetitorID: e.CompetitorID})
+		}
+
+		assert.Nil(t, retErrFunc())
+		assert.Nil(t, eventsJournal.Close())
+
+		result := replayResult(t, path, true, replayCutOff{index: 12})
+
+		assert.Equal(t, report.StatusRunning, result.Status)
+		assert.Equal(t, &report.JSONCheckpoint{Time: "09:51:48.391", Event: "left penalty laps"}, result.Checkpoint)
+
+		result = replayResult(t, path, true, replayCutOff{})
+
+		assert.Equal(t, report.StatusDNF, result.Status)
+	})
+}
@@ -0,0 +1,271 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/competition"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/config"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event/parser"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/journal"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/report"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/snapshot"
+)
+
+var errJournalNotCovered = errors.New("non-empty journal is not covered by snapshot")
+
+// reportCommand handles incoming events and makes competition report.
+func reportCommand(args []string) error {
+	flags := flag.NewFlagSet("biathlon-reporter", flag.ExitOnError)
+
+	configFilePathFlag := flags.String("config", "", "Path to configuration file")
+	printConfigFlag := flags.Bool("print-config", false, "Print current config to stdout")
+	printConfigFormatFlag := flags.String("print-config-format", "",
+		"Format of printed config: json or yaml. Default is format of config file")
+	incomingEventsFilePath := flags.String("events", "", "Path to events file")
+	reportFilePathFlag := flags.String("report", "report.txt", "Path of file to save report")
+	reportFormatFlag := flags.String("format", string(report.FormatText), "Format of report: text or json")
+	snapshotFilePathFlag := flags.String("snapshot", "",
+		"Path to snapshot file. If the file exists, handling continues after the last saved event")
+	snapshotIntervalFlag := flags.Int("snapshot-interval", 100,
+		"Amount of events between saving snapshots, 0 to save snapshot only after all events")
+	journalFilePathFlag := flags.String("journal", "", "Path to journal file to append incoming and outgoing events")
+
+	_ = flags.Parse(args)
+
+	if *configFilePathFlag == "" {
+		return errNoConfigFile
+	}
+
+	conf := config.BiathlonCompetition{}
+	err := config.Read(*configFilePathFlag, &conf)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+
+	if *printConfigFlag {
+		err = printConfig(*configFilePathFlag, *printConfigFormatFlag, conf)
+		if err != nil {
+			return err
+		}
+	}
+
+	if *incomingEventsFilePath == "" {
+		return errNoEventsFile
+	}
+
+	format, err := report.ParseFormat(*reportFormatFlag)
+	if err != nil {
+		return err
+	}
+
+	reporter := report.NewReporter(conf)
+	observer := competition.NewComposedObserver().AddObservers(event.NewLogger(os.Stdout), reporter)
+
+	var eventsJournal *journal.Journal
+	if *journalFilePathFlag != "" {
+		eventsJournal, err = journal.Open(*journalFilePathFlag)
+		if err != nil {
+			return err
+		}
+		defer eventsJournal.Close()
+
+		observer.AddObservers(eventsJournal)
+	}
+
+	biathlon, err := competition.NewBiathlon(conf, observer)
+	if err != nil {
+		return fmt.Errorf("failed to create biathlon competition: %w", err)
+	}
+
+	file, err := os.Open(*incomingEventsFilePath)
+	if err != nil {
+		return fmt.Errorf("open events file: %w", err)
+	}
+	defer file.Close()
+
+	targetsChecker := parser.NewTargetsChecker(conf)
+
+	var (
+		offset     int
+		journalSeq uint64
+	)
+
+	if *snapshotFilePathFlag != "" {
+		offset, journalSeq, err = restoreSnapshot(*snapshotFilePathFlag, conf, biathlon, reporter, targetsChecker)
+		if err != nil {
+			return fmt.Errorf("restore snapshot: %w", err)
+		}
+	}
+
+	if eventsJournal != nil {
+		err = syncJournal(eventsJournal, journalSeq)
+		if err != nil {
+			return fmt.Errorf("journal '%s': %w", *journalFilePathFlag, err)
+		}
+	}
+
+	saveSnapshot := func(handled int) error {
+		saved := snapshot.Snapshot{
+			Offset:      handled,
+			Config:      conf,
+			Competition: biathlon.Snapshot(),
+			Report:      reporter.Snapshot(),
+			Targets:     targetsChecker.Snapshot(),
+		}
+
+		if eventsJournal != nil {
+			saved.Journal = eventsJournal.Seq()
+		}
+
+		return snapshot.Write(*snapshotFilePathFlag, saved)
+	}
+
+	handler := eventsHandler{targetsChecker: targetsChecker, biathlon: biathlon, observer: observer}
+	handled := 0
+
+	lines, retErrFunc := parser.Lines(file)
+	for event, err := range parser.ParsedLines(lines) {
+		if err != nil {
+			return fmt.Errorf("parsing file: %w", err)
+		}
+
+		handled += 1
+		if handled <= offset {
+			continue
+		}
+
+		err = handler.handle(event)
+		if err != nil {
+			return err
+		}
+
+		if *snapshotFilePathFlag != "" && *snapshotIntervalFlag > 0 && handled%*snapshotIntervalFlag == 0 {
+			err = saveSnapshot(handled)
+			if err != nil {
+				return fmt.Errorf("save snapshot: %w", err)
+			}
+		}
+	}
+
+	err = retErrFunc()
+	if err != nil {
+		return fmt.Errorf("reading file: %w", err)
+	}
+
+	if *snapshotFilePathFlag != "" && handled > offset {
+		err = saveSnapshot(handled)
+		if err != nil {
+			return fmt.Errorf("save snapshot: %w", err)
+		}
+	}
+
+	if eventsJournal != nil {
+		err = eventsJournal.Err()
+		if err != nil {
+			return fmt.Errorf("journal: %w", err)
+		}
+	}
+
+	reportFile, err := os.OpenFile(*reportFilePathFlag, os.O_CREATE|os.O_TRUNC|os.O_RDWR, 0o666)
+	if err != nil {
+		return fmt.Errorf("open file for report: '%s': %w", *reportFilePathFlag, err)
+	}
+	defer reportFile.Close()
+
+	err = report.NewDocument(reporter.MakeReport(), conf).Write(reportFile, format)
+	if err != nil {
+		return fmt.Errorf("write report: %w", err)
+	}
+
+	return nil
+}
+
+// eventsHandler checks and handles incoming events one by one, it is used by report and replay commands.
+type eventsHandler struct {
+	targetsChecker *parser.TargetsChecker
+	biathlon       *competition.Biathlon
+	// observer - observer passed to competition.Biathlon.
+	observer *competition.ComposedObserver
+}
+
+// handle checks and handles single incoming event. Returns error if the event is not valid
+// or observers failed on this event.
+func (h eventsHandler) handle(incomingEvent event.Event) error {
+	err := h.targetsChecker.Check(incomingEvent)
+	if err != nil {
+		return fmt.Errorf("checking events: %w", err)
+	}
+
+	h.biathlon.HandleEvent(incomingEvent)
+
+	err = errors.Join(h.observer.Err(), h.biathlon.Err())
+	if err != nil {
+		return fmt.Errorf("handling events: %w", err)
+	}
+
+	return nil
+}
+
+// restoreSnapshot restores state of events handling from snapshot file, if it exists,
+// and returns amount of events, that are already handled, and sequence number of the last
+// journal record at the moment of saving snapshot (zero if journal was not used).
+func restoreSnapshot(
+	path string,
+	conf config.BiathlonCompetition,
+	biathlon *competition.Biathlon,
+	reporter *report.Reporter,
+	targetsChecker *parser.TargetsChecker,
+) (int, uint64, error) {
+	saved, err := snapshot.Read(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return 0, 0, nil
+	}
+
+	if err != nil {
+		return 0, 0, err
+	}
+
+	err = saved.Check(conf)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	err = biathlon.Restore(saved.Competition)
+	if err != nil {
+		return 0, 0, err
+	}
+
+	reporter.Restore(saved.Report)
+	targetsChecker.Restore(saved.Targets)
+
+	return saved.Offset, saved.Journal, nil
+}
+
+// syncJournal removes records of eventsJournal appended after the snapshot was saved with
+// journal sequence number seq, as these events are handled again. If there is no such snapshot
+// (seq is zero), all events are handled from the beginning, so journal must be empty.
+func syncJournal(eventsJournal *journal.Journal, seq uint64) error {
+	if seq == 0 && eventsJournal.Seq() != 0 {
+		return fmt.Errorf("%w: it has %d records, events would be appended twice", errJournalNotCovered, eventsJournal.Seq())
+	}
+
+	return eventsJournal.Truncate(seq)
+}
+
+func printConfig(configFilePath string, formatName string, conf config.BiathlonCompetition) error {
+	format := config.FormatFromPath(configFilePath)
+	if formatName != "" {
+		var err error
+
+		format, err = config.ParseFormat(formatName)
+		if err != nil {
+			return err
+		}
+	}
+
+	return config.Print(os.Stdout, conf, format)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/benchmark"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/competition"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/config"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event/parser"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/report"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/simulator"
+)
+
+// Benchmark_Pipeline measures throughput of the loop of report command: parsing events file,
+// checking targets, handling events by competition.Biathlon with logger and reporter, checking errors
+// of observers after each event and writing report.
+func Benchmark_Pipeline(b *testing.B) {
+	conf := config.BiathlonCompetition{
+		Laps:        5,
+		LapLen:      3000,
+		PenaltyLen:  150,
+		FiringLines: 4,
+		Start:       "09:00:00.000",
+		StartDelta:  "00:00:01",
+	}
+
+	params := simulator.DefaultParams()
+	params.Competitors = 1000
+	params.Shots = true
+
+	events, err := simulator.Generate(conf, params)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	data := bytes.Buffer{}
+	if err = simulator.Write(&data, events); err != nil {
+		b.Fatal(err)
+	}
+
+	b.ReportAllocs()
+	b.SetBytes(int64(data.Len()))
+	b.ResetTimer()
+
+	for range b.N {
+		reporter := report.NewReporter(conf)
+		observer := competition.NewComposedObserver().AddObservers(event.NewLogger(io.Discard), reporter)
+
+		biathlon, err := competition.NewBiathlon(conf, observer)
+		if err != nil {
+			b.Fatal(err)
+		}
+
+		handler := eventsHandler{targetsChecker: parser.NewTargetsChecker(conf), biathlon: biathlon, observer: observer}
+
+		lines, retErrFunc := parser.Lines(bytes.NewReader(data.Bytes()))
+		for e, parseErr := range parser.ParsedLines(lines) {
+			if parseErr != nil {
+				b.Fatal(parseErr)
+			}
+
+			if err = handler.handle(e); err != nil {
+				b.Fatal(err)
+			}
+		}
+
+		if err = retErrFunc(); err != nil {
+			b.Fatal(err)
+		}
+
+		if err = report.NewDocument(reporter.MakeReport(), conf).Write(io.Discard, report.FormatText); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	benchmark.ReportThroughput(b, b.N*len(events), "events/s", benchmark.PipelineTarget)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/config"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/simulator"
+)
+
+// simulateCommand generates events file of synthetic race.
+func simulateCommand(args []string) error {
+	flags := flag.NewFlagSet("simulate", flag.ExitOnError)
+	defaults := simulator.DefaultParams()
+
+	configFilePathFlag := flags.String("config", "", "Path to configuration file")
+	eventsFilePathFlag := flags.String("events", "", "Path of file to save events, default is stdout")
+	competitorsFlag := flags.Int("competitors", defaults.Competitors, "Amount of competitors")
+	seedFlag := flags.Uint64("seed", defaults.Seed, "Seed of random number generator")
+	speedMeanFlag := flags.Float64("speed-mean", defaults.SpeedMean, "Mean ski speed in m/s")
+	speedStdDevFlag := flags.Float64("speed-stddev", defaults.SpeedStdDev, "Standard deviation of ski speed in m/s")
+	hitProbabilityFlag := flags.Float64("hit-probability", defaults.HitProbability, "Probability to hit a target")
+	dnsRateFlag := flags.Float64("dns-rate", defaults.DNSRate, "Probability, that the competitor does not start")
+	dnfRateFlag := flags.Float64("dnf-rate", defaults.DNFRate, "Probability, that the competitor cannot continue")
+	lateStartRateFlag := flags.Float64("late-start-rate", defaults.LateStartRate,
+		"Probability, that the competitor starts after his/her start interval")
+	maxStartLatenessFlag := flags.Duration("max-start-lateness", defaults.MaxStartLateness,
+		"Maximum delay of start for competitors, who start in time")
+	shotsFlag := flags.Bool("shots", defaults.Shots, "Generate event 12 for each shot")
+
+	_ = flags.Parse(args)
+
+	if *configFilePathFlag == "" {
+		return errNoConfigFile
+	}
+
+	conf := config.BiathlonCompetition{}
+	err := config.Read(*configFilePathFlag, &conf)
+	if err != nil {
+		return fmt.Errorf("read config: %w", err)
+	}
+
+	events, err := simulator.Generate(conf, simulator.Params{
+		Competitors:      *competitorsFlag,
+		Seed:             *seedFlag,
+		SpeedMean:        *speedMeanFlag,
+		SpeedStdDev:      *speedStdDevFlag,
+		HitProbability:   *hitProbabilityFlag,
+		DNSRate:          *dnsRateFlag,
+		DNFRate:          *dnfRateFlag,
+		LateStartRate:    *lateStartRateFlag,
+		MaxStartLateness: *maxStartLatenessFlag,
+		Shots:            *shotsFlag,
+	})
+	if err != nil {
+		return err
+	}
+
+	output := os.Stdout
+	if *eventsFilePathFlag != "" {
+		output, err = os.OpenFile(*eventsFilePathFlag, os.O_CREATE|os.O_TRUNC|os.O_RDWR, 0o666)
+		if err != nil {
+			return fmt.Errorf("open file for events: '%s': %w", *eventsFilePathFlag, err)
+		}
+		defer output.Close()
+	}
+
+	err = simulator.Write(output, events)
+	if err != nil {
+		return fmt.Errorf("write events: %w", err)
+	}
+
+	return nil
+}
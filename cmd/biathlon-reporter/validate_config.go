@@ -0,0 +1,36 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/config"
+)
+
+// validateConfigCommand checks config and reports all found problems.
+func validateConfigCommand(args []string) error {
+	flags := flag.NewFlagSet("validate-config", flag.ExitOnError)
+
+	configFilePathFlag := flags.String("config", "", "Path to configuration file")
+	cupFlag := flags.Bool("cup", false, "Validate cup config instead of competition config")
+
+	_ = flags.Parse(args)
+
+	if *configFilePathFlag == "" {
+		return errNoConfigFile
+	}
+
+	var conf config.Validator = &config.BiathlonCompetition{}
+	if *cupFlag {
+		conf = &config.Cup{}
+	}
+
+	err := config.Read(*configFilePathFlag, conf)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Config %s is valid\n", *configFilePathFlag)
+
+	return nil
+}
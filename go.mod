@@ -2,10 +2,13 @@ module github.com/AleksandrMatsko/yadro-biathlon
 
 go 1.24
 
+require (
+	github.com/stretchr/testify v1.10.0
+	go.uber.org/mock v0.5.2
+	gopkg.in/yaml.v3 v3.0.1
+)
+
 require (
 	github.com/davecgh/go-spew v1.1.1 // indirect
 	github.com/pmezard/go-difflib v1.0.0 // indirect
-	github.com/stretchr/testify v1.10.0 // indirect
-	go.uber.org/mock v0.5.2 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
@@ -0,0 +1,45 @@
+// benchmark contains throughput targets of events handling and helpers to check them in benchmarks.
+package benchmark
+
+import (
+	"os"
+	"testing"
+	"time"
+)
+
+// Throughput targets, that are expected without race detector on a typical machine, see README.
+const (
+	// ParseSingleLineTarget - lines per second parsed by parser.ParseSingleLine.
+	ParseSingleLineTarget = 4_000_000
+	// ParsedLinesTarget - lines per second read and parsed by parser.Lines and parser.ParsedLines.
+	ParsedLinesTarget = 2_000_000
+	// PipelineTarget - events per second read, parsed, checked and handled by the loop of report command
+	// with logger and report.Reporter, including writing the report.
+	PipelineTarget = 500_000
+)
+
+// CheckTargetsEnv is the environment variable, which enables failing benchmarks below target, if set to "1".
+// Targets depend on the machine, so by default throughput is only reported.
+const CheckTargetsEnv = "BIATHLON_BENCH_TARGETS"
+
+// minElapsed is the minimum duration of benchmark to check the target, shorter runs are
+// used by testing package to estimate amount of iterations and are too noisy.
+const minElapsed = 500 * time.Millisecond
+
+// ReportThroughput reports amount of processed items per second as metric with the given unit.
+// If CheckTargetsEnv is set to "1", it also fails the benchmark, when throughput is less than target.
+// Target is not checked with race detector, as it slows down the code several times.
+func ReportThroughput(b *testing.B, processed int, unit string, target float64) {
+	b.Helper()
+
+	throughput := float64(processed) / b.Elapsed().Seconds()
+	b.ReportMetric(throughput, unit)
+
+	if os.Getenv(CheckTargetsEnv) != "1" || raceEnabled {
+		return
+	}
+
+	if b.Elapsed() >= minElapsed && throughput < target {
+		b.Errorf("throughput %.0f %s is less than target %.0f %s", throughput, unit, target, unit)
+	}
+}
@@ -0,0 +1,175 @@
+package competition
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
+)
+
+// OverflowPolicy defines what AsyncObserver does with new event, when its queue is full.
+type OverflowPolicy uint8
+
+// constants define possible overflow policies.
+const (
+	// OverflowBlock - NotifyWithEvent waits until there is free space in the queue.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropOldest - the oldest event in the queue is dropped to free space for the new one.
+	OverflowDropOldest
+	// OverflowDropNewest - the new event is dropped.
+	OverflowDropNewest
+)
+
+// AsyncObserverStats contains counters of AsyncObserver.
+type AsyncObserverStats struct {
+	// Delivered - amount of events passed to the wrapped observer.
+	Delivered uint64
+	// Dropped - amount of events dropped because of full queue or after Close.
+	Dropped uint64
+	// Failed - amount of delivered events, on which the wrapped observer failed or panicked.
+	Failed uint64
+	// Queued - amount of events waiting in the queue.
+	Queued int
+}
+
+// AsyncObserver is an Observer, that notifies wrapped observer from separate goroutine,
+// so slow observer does not stall the caller. Events are queued in bounded queue and delivered
+// in the order they are received. When the queue is full, OverflowPolicy is applied.
+// Failures and panics of the wrapped observer do not stop delivery, they are returned by Err.
+type AsyncObserver struct {
+	observer Observer
+	policy   OverflowPolicy
+
+	mu       sync.Mutex
+	notEmpty *sync.Cond
+	notFull  *sync.Cond
+	// queue is ring buffer with size events starting from head.
+	queue  []event.Event
+	head   int
+	size   int
+	closed bool
+	stats  AsyncObserverStats
+	errs   []error
+
+	stopped chan struct{}
+}
+
+// NewAsyncObserver creates AsyncObserver with queue of given size (at least 1) and starts its goroutine.
+// Use Close to deliver queued events and stop the goroutine.
+func NewAsyncObserver(observer Observer, queueSize int, policy OverflowPolicy) *AsyncObserver {
+	a := &AsyncObserver{
+		observer: observer,
+		policy:   policy,
+		queue:    make([]event.Event, max(queueSize, 1)),
+		stopped:  make(chan struct{}),
+	}
+
+	a.notEmpty = sync.NewCond(&a.mu)
+	a.notFull = sync.NewCond(&a.mu)
+
+	go a.deliver()
+
+	return a
+}
+
+// NotifyWithEvent puts event to the queue. Events received after Close are dropped.
+func (a *AsyncObserver) NotifyWithEvent(e event.Event) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for a.policy == OverflowBlock && a.size == len(a.queue) && !a.closed {
+		a.notFull.Wait()
+	}
+
+	if a.closed {
+		a.stats.Dropped += 1
+		return
+	}
+
+	if a.size == len(a.queue) {
+		a.stats.Dropped += 1
+
+		if a.policy == OverflowDropNewest {
+			return
+		}
+
+		a.head = (a.head + 1) % len(a.queue)
+		a.size -= 1
+	}
+
+	a.queue[(a.head+a.size)%len(a.queue)] = e
+	a.size += 1
+
+	a.notEmpty.Signal()
+}
+
+func (a *AsyncObserver) deliver() {
+	defer close(a.stopped)
+
+	for {
+		a.mu.Lock()
+		for a.size == 0 && !a.closed {
+			a.notEmpty.Wait()
+		}
+
+		if a.size == 0 {
+			a.mu.Unlock()
+			return
+		}
+
+		e := a.queue[a.head]
+		a.queue[a.head] = event.Event{}
+		a.head = (a.head + 1) % len(a.queue)
+		a.size -= 1
+
+		a.notFull.Signal()
+		a.mu.Unlock()
+
+		var err error
+		if a.observer != nil {
+			err = notify(a.observer, e)
+		}
+
+		a.mu.Lock()
+		a.stats.Delivered += 1
+
+		if err != nil {
+			a.stats.Failed += 1
+			a.errs = append(a.errs, err)
+		}
+
+		a.mu.Unlock()
+	}
+}
+
+// Stats returns current counters of delivered and dropped events.
+func (a *AsyncObserver) Stats() AsyncObserverStats {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	stats := a.stats
+	stats.Queued = a.size
+
+	return stats
+}
+
+// Err returns errors of the wrapped observer as ObserverError joined into single error,
+// nil if there were no errors.
+func (a *AsyncObserver) Err() error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	return errors.Join(a.errs...)
+}
+
+// Close stops receiving events and waits until all queued events are delivered.
+// Blocked NotifyWithEvent calls return and their events are dropped.
+func (a *AsyncObserver) Close() {
+	a.mu.Lock()
+	a.closed = true
+	a.notEmpty.Broadcast()
+	a.notFull.Broadcast()
+	a.mu.Unlock()
+
+	<-a.stopped
+}
@@ -0,0 +1,173 @@
+package competition
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/competition/mocks"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+// gatedObserver records events, delivery of each event waits for the gate.
+type gatedObserver struct {
+	gate     chan struct{}
+	received chan event.Event
+
+	mu     sync.Mutex
+	events []string
+}
+
+func newGatedObserver() *gatedObserver {
+	return &gatedObserver{
+		gate:     make(chan struct{}),
+		received: make(chan event.Event, 100),
+	}
+}
+
+func (o *gatedObserver) NotifyWithEvent(e event.Event) {
+	o.received <- e
+	<-o.gate
+
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.events = append(o.events, e.CompetitorID)
+}
+
+func (o *gatedObserver) open() {
+	close(o.gate)
+}
+
+func (o *gatedObserver) competitorIDs() []string {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return o.events
+}
+
+func givenEvent(i int) event.Event {
+	return event.Event{ID: event.CompetitorRegistration, CompetitorID: fmt.Sprint(i)}
+}
+
+func Test_AsyncObserver(t *testing.T) {
+	t.Run("with drop newest policy", func(t *testing.T) {
+		observer := newGatedObserver()
+		async := NewAsyncObserver(observer, 2, OverflowDropNewest)
+
+		async.NotifyWithEvent(givenEvent(0))
+		<-observer.received
+
+		for i := 1; i <= 4; i++ {
+			async.NotifyWithEvent(givenEvent(i))
+		}
+
+		assert.Equal(t, AsyncObserverStats{Dropped: 2, Queued: 2}, async.Stats())
+
+		observer.open()
+		async.Close()
+
+		assert.Equal(t, []string{"0", "1", "2"}, observer.competitorIDs())
+		assert.Equal(t, AsyncObserverStats{Delivered: 3, Dropped: 2}, async.Stats())
+	})
+
+	t.Run("with drop oldest policy", func(t *testing.T) {
+		observer := newGatedObserver()
+		async := NewAsyncObserver(observer, 2, OverflowDropOldest)
+
+		async.NotifyWithEvent(givenEvent(0))
+		<-observer.received
+
+		for i := 1; i <= 4; i++ {
+			async.NotifyWithEvent(givenEvent(i))
+		}
+
+		observer.open()
+		async.Close()
+
+		assert.Equal(t, []string{"0", "3", "4"}, observer.competitorIDs())
+		assert.Equal(t, AsyncObserverStats{Delivered: 3, Dropped: 2}, async.Stats())
+	})
+
+	t.Run("with block policy", func(t *testing.T) {
+		observer := newGatedObserver()
+		async := NewAsyncObserver(observer, 1, OverflowBlock)
+
+		async.NotifyWithEvent(givenEvent(0))
+		<-observer.received
+
+		async.NotifyWithEvent(givenEvent(1))
+
+		sent := make(chan struct{})
+		go func() {
+			defer close(sent)
+			async.NotifyWithEvent(givenEvent(2))
+		}()
+
+		assert.Never(t, func() bool {
+			select {
+			case <-sent:
+				return true
+			default:
+				return false
+			}
+		}, 100*time.Millisecond, 5*time.Millisecond, "NotifyWithEvent must block while queue is full")
+		assert.Equal(t, AsyncObserverStats{Queued: 1}, async.Stats())
+
+		observer.open()
+		<-sent
+		async.Close()
+
+		assert.Equal(t, []string{"0", "1", "2"}, observer.competitorIDs())
+		assert.Equal(t, AsyncObserverStats{Delivered: 3}, async.Stats())
+	})
+
+	t.Run("flush on close with concurrent producers", func(t *testing.T) {
+		observer := newGatedObserver()
+		observer.open()
+
+		async := NewAsyncObserver(observer, 10, OverflowBlock)
+
+		wg := sync.WaitGroup{}
+		for producer := range 4 {
+			wg.Add(1)
+
+			go func() {
+				defer wg.Done()
+
+				for i := range 25 {
+					async.NotifyWithEvent(givenEvent(producer*100 + i))
+				}
+			}()
+		}
+
+		wg.Wait()
+		async.Close()
+
+		assert.Len(t, observer.competitorIDs(), 100)
+		assert.Equal(t, AsyncObserverStats{Delivered: 100}, async.Stats())
+
+		async.NotifyWithEvent(givenEvent(0))
+		assert.Equal(t, AsyncObserverStats{Delivered: 100, Dropped: 1}, async.Stats())
+	})
+
+	t.Run("with panicking observer", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		observer := mock_observer.NewMockObserver(mockCtrl)
+
+		observer.EXPECT().NotifyWithEvent(givenEvent(0)).Do(func(event.Event) { panic("bad observer") })
+		observer.EXPECT().NotifyWithEvent(givenEvent(1))
+
+		async := NewAsyncObserver(observer, 2, OverflowBlock)
+
+		async.NotifyWithEvent(givenEvent(0))
+		async.NotifyWithEvent(givenEvent(1))
+		async.Close()
+
+		assert.Equal(t, AsyncObserverStats{Delivered: 2, Failed: 1}, async.Stats())
+		assert.ErrorIs(t, async.Err(), ErrObserverPanic)
+	})
+}
@@ -12,7 +12,8 @@ import (
 // Biathlon represents biathlon competition, that handles incoming events.
 type Biathlon struct {
 	rules    rules
-	observer Observer
+	observer *ComposedObserver
+	referees *referees
 }
 
 // NewBiathlon creates new Biathlon with given config and observer.
@@ -25,9 +26,11 @@ func NewBiathlon(conf config.BiathlonCompetition, observer Observer) (*Biathlon,
 
 	biathlonReferees := newReferees(competitionRules, observer)
 
+	// errors of observer and referees are collected together in order of occurrence.
 	return &Biathlon{
 		rules:    competitionRules,
-		observer: NewComposedObserver().AddObservers(observer, biathlonReferees),
+		observer: newComposedObserver(&biathlonReferees.errs).AddObservers(observer, biathlonReferees),
+		referees: biathlonReferees,
 	}, nil
 }
 
@@ -35,3 +38,10 @@ func NewBiathlon(conf config.BiathlonCompetition, observer Observer) (*Biathlon,
 func (b *Biathlon) HandleEvent(e event.Event) {
 	b.observer.NotifyWithEvent(e)
 }
+
+// Err returns errors of observer and referees collected while handling incoming and outgoing events
+// since the previous call of Err, see ComposedObserver.Err. Errors of observers, which are notified
+// by another ComposedObserver, are collected by that ComposedObserver.
+func (b *Biathlon) Err() error {
+	return b.observer.Err()
+}
@@ -37,6 +37,59 @@ func Test_Biathlon_HandleEvent(t *testing.T) {
 		biathlon.HandleEvent(givenEvent)
 	})
 
+	t.Run("with panicking observer", func(t *testing.T) {
+		observer := mock_observer.NewMockObserver(mockCtrl)
+
+		biathlon, err := NewBiathlon(conf, observer)
+		assert.Nil(t, err)
+
+		observer.EXPECT().NotifyWithEvent(givenEvent).Do(func(event.Event) { panic("bad observer") })
+
+		biathlon.HandleEvent(givenEvent)
+
+		assert.ErrorIs(t, biathlon.Err(), ErrObserverPanic)
+	})
+
+	t.Run("with observer panicking on outgoing event", func(t *testing.T) {
+		observer := mock_observer.NewMockObserver(mockCtrl)
+
+		biathlon, err := NewBiathlon(conf, observer)
+		assert.Nil(t, err)
+
+		observer.EXPECT().NotifyWithEvent(gomock.Any()).Do(func(e event.Event) {
+			if e.ID == event.CompetitorDisqualified {
+				panic("bad observer")
+			}
+		}).Times(5)
+
+		biathlon.HandleEvent(givenEvent)
+		biathlon.HandleEvent(event.Event{
+			Time:         givenEvent.Time,
+			ID:           event.StartTimeAssignment,
+			CompetitorID: givenEvent.CompetitorID,
+			Extra:        "01:05:00.000",
+		})
+
+		assert.Nil(t, biathlon.Err())
+
+		biathlon.HandleEvent(event.Event{
+			Time:         time.Date(0, time.January, 1, 1, 10, 0, 0, time.UTC),
+			ID:           event.CompetitorStarted,
+			CompetitorID: givenEvent.CompetitorID,
+		})
+
+		assert.ErrorIs(t, biathlon.Err(), ErrObserverPanic)
+
+		biathlon.HandleEvent(event.Event{
+			Time:         time.Date(0, time.January, 1, 1, 11, 0, 0, time.UTC),
+			ID:           event.CompetitorOnFiringRange,
+			CompetitorID: givenEvent.CompetitorID,
+			Extra:        "1",
+		})
+
+		assert.Nil(t, biathlon.Err())
+	})
+
 	t.Run("with nil observer", func(t *testing.T) {
 		biathlon, err := NewBiathlon(conf, nil)
 		assert.Nil(t, err)
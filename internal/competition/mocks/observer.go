@@ -1,9 +1,9 @@
 // Code generated by MockGen. DO NOT EDIT.
-// Source: github.com/AleksandrMatsko/yadro-biathlon/internal/competition (interfaces: Observer)
+// Source: github.com/AleksandrMatsko/yadro-biathlon/internal/competition (interfaces: Observer,FallibleObserver)
 //
 // Generated by this command:
 //
-//	mockgen -destination=internal/competition/mocks/observer.go -package=mock_observer github.com/AleksandrMatsko/yadro-biathlon/internal/competition Observer
+//	mockgen -destination=internal/competition/mocks/observer.go -package=mock_observer github.com/AleksandrMatsko/yadro-biathlon/internal/competition Observer,FallibleObserver
 //
 
 // Package mock_observer is a generated GoMock package.
@@ -51,3 +51,53 @@ func (mr *MockObserverMockRecorder) NotifyWithEvent(arg0 any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyWithEvent", reflect.TypeOf((*MockObserver)(nil).NotifyWithEvent), arg0)
 }
+
+// MockFallibleObserver is a mock of FallibleObserver interface.
+type MockFallibleObserver struct {
+	ctrl     *gomock.Controller
+	recorder *MockFallibleObserverMockRecorder
+	isgomock struct{}
+}
+
+// MockFallibleObserverMockRecorder is the mock recorder for MockFallibleObserver.
+type MockFallibleObserverMockRecorder struct {
+	mock *MockFallibleObserver
+}
+
+// NewMockFallibleObserver creates a new mock instance.
+func NewMockFallibleObserver(ctrl *gomock.Controller) *MockFallibleObserver {
+	mock := &MockFallibleObserver{ctrl: ctrl}
+	mock.recorder = &MockFallibleObserverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockFallibleObserver) EXPECT() *MockFallibleObserverMockRecorder {
+	return m.recorder
+}
+
+// NotifyWithEvent mocks base method.
+func (m *MockFallibleObserver) NotifyWithEvent(arg0 event.Event) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyWithEvent", arg0)
+}
+
+// NotifyWithEvent indicates an expected call of NotifyWithEvent.
+func (mr *MockFallibleObserverMockRecorder) NotifyWithEvent(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyWithEvent", reflect.TypeOf((*MockFallibleObserver)(nil).NotifyWithEvent), arg0)
+}
+
+// TryNotifyWithEvent mocks base method.
+func (m *MockFallibleObserver) TryNotifyWithEvent(arg0 event.Event) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "TryNotifyWithEvent", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// TryNotifyWithEvent indicates an expected call of TryNotifyWithEvent.
+func (mr *MockFallibleObserverMockRecorder) TryNotifyWithEvent(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "TryNotifyWithEvent", reflect.TypeOf((*MockFallibleObserver)(nil).TryNotifyWithEvent), arg0)
+}
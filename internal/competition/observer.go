@@ -1,32 +1,165 @@
 package competition
 
 import (
+	"errors"
+	"fmt"
+	"slices"
+
 	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
 )
 
+// ErrObserverPanic is wrapped in ObserverError, if observer panicked while handling event.
+var ErrObserverPanic = errors.New("observer panicked")
+
 // Observer is the interface for observing and handling incoming and outgoing events.
 type Observer interface {
 	// NotifyWithEvent should update Observer's state according to the given event.
 	NotifyWithEvent(event.Event)
 }
 
+// FallibleObserver is an Observer, that reports failures of handling events.
+// ComposedObserver calls TryNotifyWithEvent instead of NotifyWithEvent for such observers.
+type FallibleObserver interface {
+	Observer
+	// TryNotifyWithEvent is the same as NotifyWithEvent, but returns error if the event is not handled.
+	TryNotifyWithEvent(event.Event) error
+}
+
+// ObserverError describes failure of single observer on single event.
+type ObserverError struct {
+	// Observer - type of the failed observer.
+	Observer string
+	Event    event.Event
+	Err      error
+}
+
+func (e *ObserverError) Error() string {
+	return fmt.Sprintf("observer %s failed on event '%s': %s", e.Observer, e.Event.Line(), e.Err)
+}
+
+func (e *ObserverError) Unwrap() error {
+	return e.Err
+}
+
+// Filter selects events, that are passed to observer subscribed with ComposedObserver.Subscribe.
+// Zero Filter passes all events.
+type Filter struct {
+	// EventIDs - if not empty, only events with these ids are passed.
+	EventIDs []event.EventID
+	// Competitor - if not nil, only events of competitors, for which it returns true, are passed.
+	Competitor func(competitorID string) bool
+}
+
+// Match checks if the event is passed by the filter.
+func (f Filter) Match(e event.Event) bool {
+	if len(f.EventIDs) != 0 && !slices.Contains(f.EventIDs, e.ID) {
+		return false
+	}
+
+	return f.Competitor == nil || f.Competitor(e.CompetitorID)
+}
+
+// Subscription is a handle of observer subscribed with ComposedObserver.Subscribe.
+type Subscription struct {
+	composed *ComposedObserver
+	id       uint64
+}
+
+// Unsubscribe removes observer from ComposedObserver, so it is not notified with next events.
+// Repeated calls do nothing.
+func (s *Subscription) Unsubscribe() {
+	if s.composed == nil {
+		return
+	}
+
+	// new slice is created, so NotifyWithEvent, that is in progress, notifies the same observers.
+	subscribers := make([]subscriber, 0, len(s.composed.subscribers))
+	for _, sub := range s.composed.subscribers {
+		if sub.id != s.id {
+			subscribers = append(subscribers, sub)
+		}
+	}
+
+	s.composed.subscribers = subscribers
+	s.composed = nil
+}
+
+type subscriber struct {
+	id       uint64
+	observer Observer
+	filter   Filter
+}
+
 // ComposedObserver - is an Observer, that can contain several observers.
 type ComposedObserver struct {
-	observers []Observer
+	subscribers []subscriber
+	lastID      uint64
+	// errs may be shared by several ComposedObserver, see newComposedObserver.
+	errs *[]error
 }
 
 // NewComposedObserver - creates new ComposedObserver.
 func NewComposedObserver() *ComposedObserver {
+	return newComposedObserver(&[]error{})
+}
+
+// newComposedObserver creates ComposedObserver, that appends errors of observers to errs,
+// so errors of several ComposedObserver can be collected together.
+func newComposedObserver(errs *[]error) *ComposedObserver {
 	return &ComposedObserver{
-		observers: make([]Observer, 0),
+		subscribers: make([]subscriber, 0),
+		errs:        errs,
 	}
 }
 
-// NotifyWithEvent call this method for each Observer.
+// NotifyWithEvent call this method for each Observer, which filter matches the event.
+// Failure or panic of one observer does not prevent notifying others, errors are collected
+// as ObserverError and returned by Err.
 func (c *ComposedObserver) NotifyWithEvent(e event.Event) {
-	for _, observer := range c.observers {
+	for _, sub := range c.subscribers {
+		if !sub.filter.Match(e) {
+			continue
+		}
+
+		if err := notify(sub.observer, e); err != nil {
+			*c.errs = append(*c.errs, err)
+		}
+	}
+}
+
+// Err returns errors of observers collected since the previous call of Err joined into single error,
+// nil if there were no errors. So Err called after each event returns only errors of this event.
+func (c *ComposedObserver) Err() error {
+	err := errors.Join(*c.errs...)
+	*c.errs = (*c.errs)[:0]
+
+	return err
+}
+
+// notify observer with event, converting its error or panic to ObserverError.
+func notify(observer Observer, e event.Event) (err error) {
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			err = &ObserverError{
+				Observer: fmt.Sprintf("%T", observer),
+				Event:    e,
+				Err:      fmt.Errorf("%w: %v", ErrObserverPanic, recovered),
+			}
+		}
+	}()
+
+	fallible, ok := observer.(FallibleObserver)
+	if !ok {
 		observer.NotifyWithEvent(e)
+		return nil
 	}
+
+	err = fallible.TryNotifyWithEvent(e)
+	if err == nil {
+		return nil
+	}
+
+	return &ObserverError{Observer: fmt.Sprintf("%T", observer), Event: e, Err: err}
 }
 
 // AddObservers add passed observers to ComposedObserver,
@@ -34,10 +167,21 @@ func (c *ComposedObserver) NotifyWithEvent(e event.Event) {
 // ComposedObserver will not add nil observer.
 func (c *ComposedObserver) AddObservers(observers ...Observer) *ComposedObserver {
 	for i := range observers {
-		if observers[i] != nil {
-			c.observers = append(c.observers, observers[i])
-		}
+		c.Subscribe(observers[i], Filter{})
 	}
 
 	return c
 }
+
+// Subscribe adds observer to ComposedObserver, so it will be notified with events matching the filter.
+// Returned Subscription is used to remove the observer. Nil observer is not added.
+func (c *ComposedObserver) Subscribe(observer Observer, filter Filter) *Subscription {
+	if observer == nil {
+		return &Subscription{}
+	}
+
+	c.lastID += 1
+	c.subscribers = append(c.subscribers, subscriber{id: c.lastID, observer: observer, filter: filter})
+
+	return &Subscription{composed: c, id: c.lastID}
+}
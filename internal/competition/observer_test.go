@@ -1,10 +1,12 @@
 package competition
 
 import (
+	"errors"
 	"testing"
 
 	mock_observer "github.com/AleksandrMatsko/yadro-biathlon/internal/competition/mocks"
 	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
+	"github.com/stretchr/testify/assert"
 	"go.uber.org/mock/gomock"
 )
 
@@ -45,3 +47,172 @@ func Test_ComposedObserver(t *testing.T) {
 
 	composed.NotifyWithEvent(event.Event{})
 }
+
+func Test_ComposedObserver_Subscribe(t *testing.T) {
+	registered := event.Event{ID: event.CompetitorRegistration, CompetitorID: "1"}
+	started := event.Event{ID: event.CompetitorStarted, CompetitorID: "1"}
+	otherStarted := event.Event{ID: event.CompetitorStarted, CompetitorID: "2"}
+	finished := event.Event{ID: event.CompetitorFinished, CompetitorID: "2"}
+
+	t.Run("unsubscribed observer is not notified", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		first := mock_observer.NewMockObserver(mockCtrl)
+		second := mock_observer.NewMockObserver(mockCtrl)
+
+		composed := NewComposedObserver()
+		subscription := composed.Subscribe(first, Filter{})
+		composed.Subscribe(second, Filter{})
+
+		first.EXPECT().NotifyWithEvent(registered).Times(1)
+		second.EXPECT().NotifyWithEvent(registered).Times(1)
+
+		composed.NotifyWithEvent(registered)
+
+		subscription.Unsubscribe()
+		subscription.Unsubscribe()
+
+		second.EXPECT().NotifyWithEvent(started).Times(1)
+
+		composed.NotifyWithEvent(started)
+	})
+
+	t.Run("observer can unsubscribe while being notified", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		first := mock_observer.NewMockObserver(mockCtrl)
+		second := mock_observer.NewMockObserver(mockCtrl)
+
+		composed := NewComposedObserver()
+		subscription := composed.Subscribe(first, Filter{})
+		composed.Subscribe(second, Filter{})
+
+		first.EXPECT().NotifyWithEvent(registered).Do(func(event.Event) {
+			subscription.Unsubscribe()
+		}).Times(1)
+		second.EXPECT().NotifyWithEvent(registered).Times(1)
+
+		composed.NotifyWithEvent(registered)
+
+		second.EXPECT().NotifyWithEvent(started).Times(1)
+
+		composed.NotifyWithEvent(started)
+	})
+
+	t.Run("nil observer is not subscribed", func(t *testing.T) {
+		composed := NewComposedObserver()
+		subscription := composed.Subscribe(nil, Filter{})
+
+		composed.NotifyWithEvent(registered)
+		subscription.Unsubscribe()
+
+		assert.NoError(t, composed.Err())
+	})
+
+	t.Run("filter by event ids", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		observer := mock_observer.NewMockObserver(mockCtrl)
+
+		composed := NewComposedObserver()
+		composed.Subscribe(observer, Filter{EventIDs: []event.EventID{event.CompetitorStarted, event.CompetitorFinished}})
+
+		observer.EXPECT().NotifyWithEvent(started).Times(1)
+		observer.EXPECT().NotifyWithEvent(otherStarted).Times(1)
+		observer.EXPECT().NotifyWithEvent(finished).Times(1)
+
+		for _, e := range []event.Event{registered, started, otherStarted, finished} {
+			composed.NotifyWithEvent(e)
+		}
+	})
+
+	t.Run("filter by competitor", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		observer := mock_observer.NewMockObserver(mockCtrl)
+
+		composed := NewComposedObserver()
+		composed.Subscribe(observer, Filter{Competitor: func(competitorID string) bool {
+			return competitorID == "1"
+		}})
+
+		observer.EXPECT().NotifyWithEvent(registered).Times(1)
+		observer.EXPECT().NotifyWithEvent(started).Times(1)
+
+		for _, e := range []event.Event{registered, started, otherStarted, finished} {
+			composed.NotifyWithEvent(e)
+		}
+	})
+
+	t.Run("filter by event ids and competitor", func(t *testing.T) {
+		mockCtrl := gomock.NewController(t)
+		defer mockCtrl.Finish()
+
+		observer := mock_observer.NewMockObserver(mockCtrl)
+
+		composed := NewComposedObserver()
+		composed.Subscribe(observer, Filter{
+			EventIDs: []event.EventID{event.CompetitorStarted},
+			Competitor: func(competitorID string) bool {
+				return competitorID == "2"
+			},
+		})
+
+		observer.EXPECT().NotifyWithEvent(otherStarted).Times(1)
+
+		for _, e := range []event.Event{registered, started, otherStarted, finished} {
+			composed.NotifyWithEvent(e)
+		}
+	})
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write([]byte) (int, error) {
+	return 0, errors.New("disk is full")
+}
+
+func Test_ComposedObserver_errors(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	givenEvent := event.Event{ID: event.CompetitorRegistration, CompetitorID: "1"}
+	givenErr := errors.New("connection refused")
+
+	panicking := mock_observer.NewMockObserver(mockCtrl)
+	fallible := mock_observer.NewMockFallibleObserver(mockCtrl)
+	healthy := mock_observer.NewMockObserver(mockCtrl)
+
+	composed := NewComposedObserver().AddObservers(panicking, fallible, event.NewLogger(failingWriter{}), healthy)
+
+	assert.Nil(t, composed.Err())
+
+	panicking.EXPECT().NotifyWithEvent(givenEvent).Do(func(event.Event) { panic("bad observer") })
+	fallible.EXPECT().TryNotifyWithEvent(givenEvent).Return(givenErr)
+	healthy.EXPECT().NotifyWithEvent(givenEvent).Times(1)
+
+	composed.NotifyWithEvent(givenEvent)
+
+	err := composed.Err()
+	assert.ErrorIs(t, err, ErrObserverPanic)
+	assert.ErrorIs(t, err, givenErr)
+
+	var observerErr *ObserverError
+	assert.ErrorAs(t, err, &observerErr)
+	assert.Equal(t, "*mock_observer.MockObserver", observerErr.Observer)
+	assert.Equal(t, givenEvent, observerErr.Event)
+
+	assert.Equal(t,
+		"observer *mock_observer.MockObserver failed on event '[00:00:00.000] 1 1': observer panicked: bad observer\n"+
+			"observer *mock_observer.MockFallibleObserver failed on event '[00:00:00.000] 1 1': connection refused\n"+
+			"observer *event.Logger failed on event '[00:00:00.000] 1 1': disk is full",
+		err.Error())
+
+	t.Run("errors are returned once", func(t *testing.T) {
+		assert.Nil(t, composed.Err())
+	})
+}
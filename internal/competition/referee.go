@@ -1,8 +1,12 @@
 package competition
 
 import (
+	"fmt"
+	"maps"
+	"slices"
 	"time"
 
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/config"
 	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
 	"github.com/AleksandrMatsko/yadro-biathlon/internal/event/parser"
 )
@@ -11,15 +15,26 @@ import (
 type referees struct {
 	rules              rules
 	root               Observer
-	competitorReferees map[string]Observer
+	competitorReferees map[string]*competitorReferees
+	// lappingReferee is nil if lapped competitors are not pulled out of the race.
+	lappingReferee *observeLappingReferee
+	// errs - errors of observers notified about outgoing events, shared by roots of all competitors
+	// and by ComposedObserver of Biathlon.
+	errs []error
 }
 
 func newReferees(rules rules, rootObserver Observer) *referees {
-	return &referees{
+	r := &referees{
 		rules:              rules,
 		root:               rootObserver,
-		competitorReferees: make(map[string]Observer),
+		competitorReferees: make(map[string]*competitorReferees),
 	}
+
+	if rules.PullLapped {
+		r.lappingReferee = newObserveLappingReferee(r.competitorReferees, rules.Laps)
+	}
+
+	return r
 }
 
 func (r *referees) NotifyWithEvent(e event.Event) {
@@ -29,14 +44,49 @@ func (r *referees) NotifyWithEvent(e event.Event) {
 	}
 
 	if e.ID == event.CompetitorRegistration {
-		obs = NewComposedObserver().
-			AddObservers(
-				newObserverStartReferee(r.root, r.rules.MaxStartDelta),
-				newObserveFinishReferee(r.root, r.rules.Laps))
+		obs = r.newCompetitorReferees()
 		r.competitorReferees[e.CompetitorID] = obs
 	}
 
 	obs.NotifyWithEvent(e)
+
+	if r.lappingReferee != nil {
+		r.lappingReferee.NotifyWithEvent(e)
+	}
+}
+
+// competitorReferees contains referees of single competitor.
+type competitorReferees struct {
+	// root notifies root observer and referees of the competitor about outgoing events,
+	// so referees know about disqualifications generated by other referees.
+	root    *ComposedObserver
+	start   *observeStartReferee
+	jury    *observeJuryReferee
+	penalty *observePenaltyReferee
+	finish  *observeFinishReferee
+}
+
+func (r *referees) newCompetitorReferees() *competitorReferees {
+	root := newComposedObserver(&r.errs)
+
+	c := &competitorReferees{
+		root:    root,
+		start:   newObserverStartReferee(root, r.rules.MaxStartDelta),
+		jury:    newObserveJuryReferee(root),
+		penalty: newObservePenaltyReferee(root, r.rules.TargetsPerFiringLine, r.rules.FiringLineLaps),
+		finish:  newObserveFinishReferee(r.root, r.rules.Laps),
+	}
+
+	root.AddObservers(r.root, c.finish, c.jury, c.penalty)
+
+	return c
+}
+
+func (c *competitorReferees) NotifyWithEvent(e event.Event) {
+	c.start.NotifyWithEvent(e)
+	c.jury.NotifyWithEvent(e)
+	c.penalty.NotifyWithEvent(e)
+	c.finish.NotifyWithEvent(e)
 }
 
 // observeStartReferee is responsible for checking competitor disqualification
@@ -74,6 +124,11 @@ func (r *observeStartReferee) NotifyWithEvent(e event.Event) {
 				Time:         e.Time,
 				ID:           event.CompetitorDisqualified,
 				CompetitorID: e.CompetitorID,
+				Extra: event.Disqualification{
+					Reason: event.ReasonLateStart,
+					Text: fmt.Sprintf("late start by %.3f seconds, allowed %.3f",
+						actualDelta.Seconds(), r.maxStartDelta.Seconds()),
+				}.String(),
 			})
 		}
 	}
@@ -84,17 +139,22 @@ type competitorState string
 const (
 	notStarted     competitorState = "NotStarted"
 	running        competitorState = "Running"
-	disqualified   competitorState = "Disqualified"
 	cannotContinue competitorState = "CannotContinue"
+	lapped         competitorState = "Lapped"
 	finished       competitorState = "Finished"
 )
 
 // observeFinishReferee is responsible for checking competitor race finish.
+// Laps of disqualified competitor are still counted, so if the competitor is reinstated
+// by the jury after completing all laps, finish is announced with time of the reinstatement,
+// so outgoing events stay in chronological order.
 type observeFinishReferee struct {
 	root            Observer
 	lapsCount       uint32
 	lapsCompleted   uint32
 	competitorState competitorState
+	disqualified    bool
+	finishAnnounced bool
 }
 
 func newObserveFinishReferee(rootObserver Observer, laps uint32) *observeFinishReferee {
@@ -107,6 +167,18 @@ func newObserveFinishReferee(rootObserver Observer, laps uint32) *observeFinishR
 }
 
 func (r *observeFinishReferee) NotifyWithEvent(e event.Event) {
+	if e.ID == event.CompetitorDisqualified {
+		r.disqualified = true
+		return
+	}
+
+	if e.ID == event.JuryReinstatement && r.disqualified {
+		r.disqualified = false
+		r.announceFinish(e)
+
+		return
+	}
+
 	if r.competitorState == notStarted && e.ID == event.CompetitorStarted {
 		r.competitorState = running
 		return
@@ -117,8 +189,8 @@ func (r *observeFinishReferee) NotifyWithEvent(e event.Event) {
 		return
 	}
 
-	if r.competitorState == running && e.ID == event.CompetitorDisqualified {
-		r.competitorState = disqualified
+	if r.competitorState == running && e.ID == event.CompetitorLapped {
+		r.competitorState = lapped
 		return
 	}
 
@@ -127,13 +199,187 @@ func (r *observeFinishReferee) NotifyWithEvent(e event.Event) {
 
 		if r.lapsCount == r.lapsCompleted {
 			r.competitorState = finished
-			r.root.NotifyWithEvent(event.Event{
-				Time:         e.Time,
-				ID:           event.CompetitorFinished,
-				CompetitorID: e.CompetitorID,
-			})
+			r.announceFinish(e)
 		}
 
 		return
 	}
 }
+
+// announceFinish notifies root observer about finish of the competitor at the time of the given event,
+// if the competitor has completed all laps, is not disqualified and the finish has not been announced yet.
+func (r *observeFinishReferee) announceFinish(e event.Event) {
+	if r.competitorState != finished || r.disqualified || r.finishAnnounced {
+		return
+	}
+
+	r.finishAnnounced = true
+	r.root.NotifyWithEvent(event.Event{
+		Time:         e.Time,
+		ID:           event.CompetitorFinished,
+		CompetitorID: e.CompetitorID,
+	})
+}
+
+// observeJuryReferee is responsible for disqualification of the competitor by the jury.
+type observeJuryReferee struct {
+	root         Observer
+	disqualified bool
+}
+
+func newObserveJuryReferee(rootObserver Observer) *observeJuryReferee {
+	return &observeJuryReferee{
+		root: rootObserver,
+	}
+}
+
+func (r *observeJuryReferee) NotifyWithEvent(e event.Event) {
+	switch e.ID {
+	case event.CompetitorDisqualified:
+		r.disqualified = true
+	case event.JuryReinstatement:
+		r.disqualified = false
+	case event.JuryDisqualification:
+		if r.disqualified {
+			return
+		}
+
+		r.root.NotifyWithEvent(event.Event{
+			Time:         e.Time,
+			ID:           event.CompetitorDisqualified,
+			CompetitorID: e.CompetitorID,
+			Extra: event.Disqualification{
+				Reason: event.ReasonJuryDecision,
+				Text:   "jury decision: " + e.Extra,
+			}.String(),
+		})
+	}
+}
+
+// observePenaltyReferee is responsible for checking that the competitor runs penalty laps
+// after shooting with misses before the end of the main lap.
+type observePenaltyReferee struct {
+	root                 Observer
+	targetsPerFiringLine []uint32
+	firingLines          *parser.FiringLines
+	// shooting is true after allowed visit of firing line until leaving it.
+	shooting     bool
+	targets      uint32
+	hitTargets   map[uint32]struct{}
+	penaltyLaps  uint32
+	disqualified bool
+}
+
+func newObservePenaltyReferee(rootObserver Observer, targetsPerFiringLine []uint32, firingLineLaps []uint32) *observePenaltyReferee {
+	return &observePenaltyReferee{
+		root:                 rootObserver,
+		targetsPerFiringLine: targetsPerFiringLine,
+		firingLines:          parser.NewFiringLines(uint32(len(targetsPerFiringLine)), firingLineLaps),
+		hitTargets:           make(map[uint32]struct{}, config.DefaultTargets),
+	}
+}
+
+func (r *observePenaltyReferee) NotifyWithEvent(e event.Event) {
+	switch e.ID {
+	case event.CompetitorDisqualified:
+		r.disqualified = true
+	case event.JuryReinstatement:
+		r.disqualified = false
+		r.penaltyLaps = 0
+	case event.CompetitorOnFiringRange:
+		// visits, that are not allowed, are not counted as shootings in the same way as by reporter.
+		firingLine, err := r.firingLines.Visit(e.Extra)
+		r.shooting = err == nil
+
+		if r.shooting {
+			r.targets = r.targetsPerFiringLine[firingLine]
+			clear(r.hitTargets)
+		}
+	case event.TargetHit:
+		target, err := parser.ParseTarget(e.Extra)
+		if err == nil && r.shooting && target <= r.targets {
+			r.hitTargets[target] = struct{}{}
+		}
+	case event.ShotFired:
+		shot, err := parser.ParseShot(e.Extra)
+		if err == nil && r.shooting && shot.Hit && shot.Number <= r.targets {
+			r.hitTargets[shot.Number] = struct{}{}
+		}
+	case event.CompetitorLeftFiringRange:
+		if r.shooting {
+			r.penaltyLaps = r.targets - uint32(len(r.hitTargets))
+			r.shooting = false
+		}
+	case event.CompetitorEnterPenaltyLaps:
+		r.penaltyLaps = 0
+	case event.CompetitorEndedMainLap:
+		r.firingLines.EndLap()
+
+		if r.penaltyLaps == 0 || r.disqualified {
+			return
+		}
+
+		r.root.NotifyWithEvent(event.Event{
+			Time:         e.Time,
+			ID:           event.CompetitorDisqualified,
+			CompetitorID: e.CompetitorID,
+			Extra: event.Disqualification{
+				Reason: event.ReasonMissedPenalty,
+				Text:   fmt.Sprintf("missed %d penalty laps", r.penaltyLaps),
+			}.String(),
+		})
+		r.penaltyLaps = 0
+	}
+}
+
+// observeLappingReferee is responsible for pulling out competitors lapped by the leader.
+// The competitor is lapped, if another competitor ends the main lap being more than
+// one lap ahead of him/her.
+type observeLappingReferee struct {
+	competitors   map[string]*competitorReferees
+	lapsCount     uint32
+	lapsCompleted map[string]uint32
+	onCourse      map[string]struct{}
+}
+
+func newObserveLappingReferee(competitors map[string]*competitorReferees, laps uint32) *observeLappingReferee {
+	return &observeLappingReferee{
+		competitors:   competitors,
+		lapsCount:     laps,
+		lapsCompleted: make(map[string]uint32),
+		onCourse:      make(map[string]struct{}),
+	}
+}
+
+func (r *observeLappingReferee) NotifyWithEvent(e event.Event) {
+	switch e.ID {
+	case event.CompetitorStarted:
+		r.onCourse[e.CompetitorID] = struct{}{}
+	case event.CompetitorCannotContinue:
+		delete(r.onCourse, e.CompetitorID)
+	case event.CompetitorEndedMainLap:
+		if _, ok := r.onCourse[e.CompetitorID]; !ok {
+			return
+		}
+
+		r.lapsCompleted[e.CompetitorID] += 1
+
+		laps := r.lapsCompleted[e.CompetitorID]
+		if laps == r.lapsCount {
+			delete(r.onCourse, e.CompetitorID)
+		}
+
+		for _, competitorID := range slices.Sorted(maps.Keys(r.onCourse)) {
+			if r.lapsCompleted[competitorID]+1 >= laps {
+				continue
+			}
+
+			delete(r.onCourse, competitorID)
+			r.competitors[competitorID].root.NotifyWithEvent(event.Event{
+				Time:         e.Time,
+				ID:           event.CompetitorLapped,
+				CompetitorID: competitorID,
+			})
+		}
+	}
+}
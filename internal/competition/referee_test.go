@@ -69,6 +69,7 @@ func Test_observeStartReferee(t *testing.T) {
 			Time:         competitorStartedEvent.Time,
 			ID:           event.CompetitorDisqualified,
 			CompetitorID: competitorStartedEvent.CompetitorID,
+			Extra:        "lateStart late start by 60.000 seconds, allowed 1.000",
 		}).Times(1)
 
 		referee.NotifyWithEvent(competitorStartedEvent)
@@ -136,6 +137,32 @@ func Test_observeFinishReferee(t *testing.T) {
 		referee.NotifyWithEvent(mainLapEndEvent)
 	})
 
+	t.Run("when competitor is reinstated after all laps", func(t *testing.T) {
+		t.Parallel()
+
+		rootObserver := mock_observer.NewMockObserver(mockCtrl)
+
+		var lapsCount uint32 = 2
+
+		referee := newObserveFinishReferee(rootObserver, lapsCount)
+
+		referee.NotifyWithEvent(event.Event{ID: event.CompetitorDisqualified})
+		referee.NotifyWithEvent(event.Event{ID: event.CompetitorStarted})
+		referee.NotifyWithEvent(mainLapEndEvent)
+		referee.NotifyWithEvent(mainLapEndEvent)
+
+		reinstatementTime := mainLapEndEvent.Time.Add(time.Hour)
+
+		rootObserver.EXPECT().NotifyWithEvent(event.Event{
+			Time:         reinstatementTime,
+			ID:           event.CompetitorFinished,
+			CompetitorID: mainLapEndEvent.CompetitorID,
+		}).Times(1)
+
+		referee.NotifyWithEvent(event.Event{Time: reinstatementTime, ID: event.JuryReinstatement, CompetitorID: mainLapEndEvent.CompetitorID})
+		referee.NotifyWithEvent(event.Event{Time: reinstatementTime, ID: event.JuryReinstatement, CompetitorID: mainLapEndEvent.CompetitorID})
+	})
+
 	t.Run("when competitor can't continue", func(t *testing.T) {
 		t.Parallel()
 
@@ -152,4 +179,171 @@ func Test_observeFinishReferee(t *testing.T) {
 		referee.NotifyWithEvent(mainLapEndEvent)
 		referee.NotifyWithEvent(mainLapEndEvent)
 	})
+
+	t.Run("with hit shots", func(t *testing.T) {
+		t.Parallel()
+
+		rootObserver := mock_observer.NewMockObserver(mockCtrl)
+
+		referee := newObservePenaltyReferee(rootObserver, []uint32{5}, nil)
+
+		referee.NotifyWithEvent(event.Event{ID: event.CompetitorOnFiringRange, Extra: "1"})
+		for _, shot := range []string{"1 hit", "2 hit", "3 hit", "4 hit", "5 hit"} {
+			referee.NotifyWithEvent(event.Event{ID: event.ShotFired, Extra: shot})
+		}
+		referee.NotifyWithEvent(event.Event{ID: event.CompetitorLeftFiringRange})
+
+		referee.NotifyWithEvent(mainLapEndEvent)
+	})
+}
+
+func Test_observeJuryReferee(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	eventTime, _ := parser.ParseTime("11:00:00.000")
+
+	juryDisqualification := event.Event{
+		Time:         eventTime,
+		ID:           event.JuryDisqualification,
+		CompetitorID: "petya",
+		Extra:        "unsportsmanlike conduct",
+	}
+
+	t.Run("with disqualification by the jury", func(t *testing.T) {
+		t.Parallel()
+
+		rootObserver := mock_observer.NewMockObserver(mockCtrl)
+
+		referee := newObserveJuryReferee(rootObserver)
+
+		rootObserver.EXPECT().NotifyWithEvent(event.Event{
+			Time:         eventTime,
+			ID:           event.CompetitorDisqualified,
+			CompetitorID: juryDisqualification.CompetitorID,
+			Extra:        "juryDecision jury decision: unsportsmanlike conduct",
+		}).Times(1)
+
+		referee.NotifyWithEvent(juryDisqualification)
+	})
+
+	t.Run("when competitor is already disqualified", func(t *testing.T) {
+		t.Parallel()
+
+		rootObserver := mock_observer.NewMockObserver(mockCtrl)
+
+		referee := newObserveJuryReferee(rootObserver)
+
+		referee.NotifyWithEvent(event.Event{ID: event.CompetitorDisqualified})
+		referee.NotifyWithEvent(juryDisqualification)
+	})
+}
+
+func Test_observePenaltyReferee(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	eventTime, _ := parser.ParseTime("10:30:25.321")
+
+	shoot := func(referee Observer, hits ...string) {
+		referee.NotifyWithEvent(event.Event{ID: event.CompetitorOnFiringRange, Extra: "1"})
+		for _, target := range hits {
+			referee.NotifyWithEvent(event.Event{ID: event.TargetHit, Extra: target})
+		}
+		referee.NotifyWithEvent(event.Event{ID: event.CompetitorLeftFiringRange})
+	}
+
+	mainLapEndEvent := event.Event{
+		Time:         eventTime,
+		ID:           event.CompetitorEndedMainLap,
+		CompetitorID: "kolya",
+	}
+
+	t.Run("with penalty laps", func(t *testing.T) {
+		t.Parallel()
+
+		rootObserver := mock_observer.NewMockObserver(mockCtrl)
+
+		referee := newObservePenaltyReferee(rootObserver, []uint32{5, 5}, nil)
+
+		shoot(referee, "1", "2", "3", "4", "5")
+		referee.NotifyWithEvent(mainLapEndEvent)
+
+		shoot(referee, "1", "2")
+		referee.NotifyWithEvent(event.Event{ID: event.CompetitorEnterPenaltyLaps})
+		referee.NotifyWithEvent(event.Event{ID: event.CompetitorLeftPenaltyLaps})
+		referee.NotifyWithEvent(mainLapEndEvent)
+	})
+
+	t.Run("with missed penalty laps", func(t *testing.T) {
+		t.Parallel()
+
+		rootObserver := mock_observer.NewMockObserver(mockCtrl)
+
+		referee := newObservePenaltyReferee(rootObserver, []uint32{5}, nil)
+
+		shoot(referee, "1", "2", "2", "7")
+
+		rootObserver.EXPECT().NotifyWithEvent(event.Event{
+			Time:         eventTime,
+			ID:           event.CompetitorDisqualified,
+			CompetitorID: mainLapEndEvent.CompetitorID,
+			Extra:        "missedPenalty missed 3 penalty laps",
+		}).Times(1)
+
+		referee.NotifyWithEvent(mainLapEndEvent)
+		referee.NotifyWithEvent(mainLapEndEvent)
+	})
+
+	t.Run("with not allowed visits of firing lines", func(t *testing.T) {
+		t.Parallel()
+
+		rootObserver := mock_observer.NewMockObserver(mockCtrl)
+
+		referee := newObservePenaltyReferee(rootObserver, []uint32{5, 5}, []uint32{2, 3})
+
+		// firing line is not placed at the end of the first lap.
+		shoot(referee, "1", "2")
+		referee.NotifyWithEvent(mainLapEndEvent)
+
+		shoot(referee, "1", "2", "3", "4", "5")
+		referee.NotifyWithEvent(mainLapEndEvent)
+
+		// the first firing line has been visited already.
+		shoot(referee)
+		referee.NotifyWithEvent(mainLapEndEvent)
+	})
+}
+
+func Test_referees_withPullLapped(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	rootObserver := mock_observer.NewMockObserver(mockCtrl)
+
+	eventTime, _ := parser.ParseTime("10:30:00.000")
+
+	lappedEvent := event.Event{
+		Time:         eventTime,
+		ID:           event.CompetitorLapped,
+		CompetitorID: "2",
+	}
+
+	rootObserver.EXPECT().NotifyWithEvent(lappedEvent).Times(1)
+	rootObserver.EXPECT().NotifyWithEvent(gomock.Any()).AnyTimes()
+
+	r := newReferees(rules{Laps: 3, MaxStartDelta: time.Hour, PullLapped: true}, rootObserver)
+
+	for _, competitorID := range []string{"1", "2", "3"} {
+		r.NotifyWithEvent(event.Event{ID: event.CompetitorRegistration, CompetitorID: competitorID})
+		r.NotifyWithEvent(event.Event{ID: event.CompetitorStarted, CompetitorID: competitorID})
+	}
+
+	r.NotifyWithEvent(event.Event{ID: event.CompetitorCannotContinue, CompetitorID: "3"})
+	r.NotifyWithEvent(event.Event{ID: event.CompetitorEndedMainLap, CompetitorID: "1"})
+	r.NotifyWithEvent(event.Event{ID: event.CompetitorEndedMainLap, CompetitorID: "2"})
+	r.NotifyWithEvent(event.Event{ID: event.CompetitorEndedMainLap, CompetitorID: "1"})
+	r.NotifyWithEvent(event.Event{Time: eventTime, ID: event.CompetitorEndedMainLap, CompetitorID: "1"})
+	r.NotifyWithEvent(event.Event{ID: event.CompetitorEndedMainLap, CompetitorID: "2"})
+	r.NotifyWithEvent(event.Event{ID: event.CompetitorEndedMainLap, CompetitorID: "2"})
 }
@@ -8,8 +8,11 @@ import (
 )
 
 type rules struct {
-	Laps          uint32
-	MaxStartDelta time.Duration
+	Laps                 uint32
+	MaxStartDelta        time.Duration
+	TargetsPerFiringLine []uint32
+	FiringLineLaps       []uint32
+	PullLapped           bool
 }
 
 func fromConfig(conf config.BiathlonCompetition) (rules, error) {
@@ -19,7 +22,10 @@ func fromConfig(conf config.BiathlonCompetition) (rules, error) {
 	}
 
 	return rules{
-		Laps:          conf.Laps,
-		MaxStartDelta: parsedTime.Sub(time.Date(0, time.January, 1, 0, 0, 0, 0, parsedTime.Location())),
+		Laps:                 conf.Laps,
+		MaxStartDelta:        parsedTime.Sub(time.Date(0, time.January, 1, 0, 0, 0, 0, parsedTime.Location())),
+		TargetsPerFiringLine: conf.TargetsPerFiringLine(),
+		FiringLineLaps:       conf.FiringLineLaps,
+		PullLapped:           conf.PullLapped,
 	}, nil
 }
@@ -23,8 +23,9 @@ func Test_rules_fromConfig(t *testing.T) {
 
 	t.Run("with ok delta", func(t *testing.T) {
 		conf := config.BiathlonCompetition{
-			Laps:       3,
-			StartDelta: "00:01:30",
+			Laps:        3,
+			FiringLines: 2,
+			StartDelta:  "00:01:30",
 		}
 
 		gotRules, err := fromConfig(conf)
@@ -32,8 +33,9 @@ func Test_rules_fromConfig(t *testing.T) {
 		assert.Nil(t, err)
 		assert.Equal(t,
 			rules{
-				Laps:          conf.Laps,
-				MaxStartDelta: time.Minute + 30*time.Second,
+				Laps:                 conf.Laps,
+				MaxStartDelta:        time.Minute + 30*time.Second,
+				TargetsPerFiringLine: []uint32{config.DefaultTargets, config.DefaultTargets},
 			},
 			gotRules)
 	})
@@ -0,0 +1,141 @@
+package competition
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event/parser"
+)
+
+// BiathlonSnapshot is serializable state of Biathlon, see Biathlon.Snapshot.
+type BiathlonSnapshot struct {
+	// Competitors - state of referees of each registered competitor by competitorID.
+	Competitors map[string]CompetitorSnapshot `json:"competitors"`
+	// Lapping - state of the referee pulling out lapped competitors. Set only if PullLapped is enabled.
+	Lapping *LappingSnapshot `json:"lapping,omitempty"`
+}
+
+// CompetitorSnapshot is serializable state of referees of single competitor.
+type CompetitorSnapshot struct {
+	Started           bool      `json:"started"`
+	AssignedStartTime time.Time `json:"assignedStartTime"`
+	// State - state of competitor on course: NotStarted, Running, CannotContinue, Lapped or Finished.
+	State           string `json:"state"`
+	LapsCompleted   uint32 `json:"lapsCompleted"`
+	FinishAnnounced bool   `json:"finishAnnounced"`
+	Disqualified    bool   `json:"disqualified"`
+	// FiringLines - visits of firing lines, see parser.FiringLines.
+	FiringLines parser.FiringLinesSnapshot `json:"firingLines"`
+	// Shooting - if the competitor is on allowed firing line.
+	Shooting bool `json:"shooting"`
+	// Targets - amount of targets on the current (or last) firing line.
+	Targets     uint32   `json:"targets"`
+	HitTargets  []uint32 `json:"hitTargets,omitempty"`
+	PenaltyLaps uint32   `json:"penaltyLaps"`
+}
+
+// LappingSnapshot is serializable state of the referee pulling out lapped competitors.
+type LappingSnapshot struct {
+	LapsCompleted map[string]uint32 `json:"lapsCompleted"`
+	OnCourse      []string          `json:"onCourse"`
+}
+
+// Snapshot returns current state of b, that can be restored later with Restore.
+func (b *Biathlon) Snapshot() BiathlonSnapshot {
+	return b.referees.snapshot()
+}
+
+// Restore replaces state of b with the given snapshot. b must be created with the same config
+// as the Biathlon the snapshot is taken from.
+func (b *Biathlon) Restore(snapshot BiathlonSnapshot) error {
+	return b.referees.restore(snapshot)
+}
+
+func (r *referees) snapshot() BiathlonSnapshot {
+	snapshot := BiathlonSnapshot{
+		Competitors: make(map[string]CompetitorSnapshot, len(r.competitorReferees)),
+	}
+
+	for competitorID, c := range r.competitorReferees {
+		snapshot.Competitors[competitorID] = c.snapshot()
+	}
+
+	if r.lappingReferee != nil {
+		snapshot.Lapping = &LappingSnapshot{
+			LapsCompleted: maps.Clone(r.lappingReferee.lapsCompleted),
+			OnCourse:      slices.Sorted(maps.Keys(r.lappingReferee.onCourse)),
+		}
+	}
+
+	return snapshot
+}
+
+func (r *referees) restore(snapshot BiathlonSnapshot) error {
+	if (snapshot.Lapping != nil) != (r.lappingReferee != nil) {
+		return errors.New("snapshot is taken with different pullLapped setting")
+	}
+
+	clear(r.competitorReferees)
+
+	for competitorID, competitorSnapshot := range snapshot.Competitors {
+		c := r.newCompetitorReferees()
+		c.restore(competitorSnapshot)
+		r.competitorReferees[competitorID] = c
+	}
+
+	if r.lappingReferee != nil {
+		r.lappingReferee.lapsCompleted = maps.Clone(snapshot.Lapping.LapsCompleted)
+		if r.lappingReferee.lapsCompleted == nil {
+			r.lappingReferee.lapsCompleted = make(map[string]uint32)
+		}
+
+		r.lappingReferee.onCourse = make(map[string]struct{}, len(snapshot.Lapping.OnCourse))
+		for _, competitorID := range snapshot.Lapping.OnCourse {
+			r.lappingReferee.onCourse[competitorID] = struct{}{}
+		}
+	}
+
+	return nil
+}
+
+func (c *competitorReferees) snapshot() CompetitorSnapshot {
+	return CompetitorSnapshot{
+		Started:           c.start.started,
+		AssignedStartTime: c.start.assignedStartTime,
+		State:             string(c.finish.competitorState),
+		LapsCompleted:     c.finish.lapsCompleted,
+		FinishAnnounced:   c.finish.finishAnnounced,
+		Disqualified:      c.finish.disqualified,
+		FiringLines:       c.penalty.firingLines.Snapshot(),
+		Shooting:          c.penalty.shooting,
+		Targets:           c.penalty.targets,
+		HitTargets:        slices.Sorted(maps.Keys(c.penalty.hitTargets)),
+		PenaltyLaps:       c.penalty.penaltyLaps,
+	}
+}
+
+// restore sets state of referees. All referees track disqualification of the competitor
+// in the same way, so it is stored once.
+func (c *competitorReferees) restore(snapshot CompetitorSnapshot) {
+	c.start.started = snapshot.Started
+	c.start.assignedStartTime = snapshot.AssignedStartTime
+
+	c.finish.competitorState = competitorState(snapshot.State)
+	c.finish.lapsCompleted = snapshot.LapsCompleted
+	c.finish.finishAnnounced = snapshot.FinishAnnounced
+	c.finish.disqualified = snapshot.Disqualified
+
+	c.jury.disqualified = snapshot.Disqualified
+
+	c.penalty.disqualified = snapshot.Disqualified
+	c.penalty.firingLines.Restore(snapshot.FiringLines)
+	c.penalty.shooting = snapshot.Shooting
+	c.penalty.targets = snapshot.Targets
+	c.penalty.penaltyLaps = snapshot.PenaltyLaps
+
+	for _, target := range snapshot.HitTargets {
+		c.penalty.hitTargets[target] = struct{}{}
+	}
+}
@@ -0,0 +1,71 @@
+package competition
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	mock_observer "github.com/AleksandrMatsko/yadro-biathlon/internal/competition/mocks"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event/parser"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+func Test_referees_snapshot(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	eventTime, _ := parser.ParseTime("10:30:00.000")
+	givenRules := rules{Laps: 3, MaxStartDelta: time.Hour, TargetsPerFiringLine: []uint32{5}, PullLapped: true}
+
+	r := newReferees(givenRules, nil)
+
+	for _, competitorID := range []string{"1", "2"} {
+		r.NotifyWithEvent(event.Event{ID: event.CompetitorRegistration, CompetitorID: competitorID})
+		r.NotifyWithEvent(event.Event{ID: event.CompetitorStarted, CompetitorID: competitorID})
+	}
+
+	r.NotifyWithEvent(event.Event{ID: event.CompetitorOnFiringRange, CompetitorID: "2", Extra: "1"})
+	r.NotifyWithEvent(event.Event{ID: event.TargetHit, CompetitorID: "2", Extra: "3"})
+	r.NotifyWithEvent(event.Event{ID: event.CompetitorEndedMainLap, CompetitorID: "1"})
+
+	data, err := json.Marshal(r.snapshot())
+	assert.Nil(t, err)
+
+	snapshot := BiathlonSnapshot{}
+	assert.Nil(t, json.Unmarshal(data, &snapshot))
+
+	assert.Equal(t, CompetitorSnapshot{
+		Started:       true,
+		State:         string(running),
+		LapsCompleted: 1,
+		FiringLines:   parser.FiringLinesSnapshot{LapsCompleted: 1},
+	}, snapshot.Competitors["1"])
+	assert.Equal(t, []uint32{3}, snapshot.Competitors["2"].HitTargets)
+	assert.Equal(t, &LappingSnapshot{
+		LapsCompleted: map[string]uint32{"1": 1},
+		OnCourse:      []string{"1", "2"},
+	}, snapshot.Lapping)
+
+	t.Run("restore with the same rules", func(t *testing.T) {
+		rootObserver := mock_observer.NewMockObserver(mockCtrl)
+
+		restored := newReferees(givenRules, rootObserver)
+		assert.Nil(t, restored.restore(snapshot))
+		assert.Equal(t, snapshot, restored.snapshot())
+
+		rootObserver.EXPECT().NotifyWithEvent(event.Event{
+			Time:         eventTime,
+			ID:           event.CompetitorLapped,
+			CompetitorID: "2",
+		}).Times(1)
+
+		restored.NotifyWithEvent(event.Event{Time: eventTime, ID: event.CompetitorEndedMainLap, CompetitorID: "1"})
+	})
+
+	t.Run("restore with different rules", func(t *testing.T) {
+		restored := newReferees(rules{Laps: 3}, nil)
+		assert.NotNil(t, restored.restore(snapshot))
+	})
+}
@@ -3,8 +3,18 @@ package config
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"reflect"
+)
+
+// Limits of targets on firing line.
+const (
+	// DefaultTargets is the number of targets on firing line if it is not set in config.
+	DefaultTargets = 5
+	// MaxTargets is the maximum number of targets on firing line.
+	MaxTargets = 255
 )
 
 // BiathlonCompetition represents config for biathlon competition.
@@ -13,33 +23,145 @@ type BiathlonCompetition struct {
 	Laps uint32 `json:"laps"`
 	// LapLen - length of each main lap.
 	LapLen uint32 `json:"lapLen"`
+	// LapLens - optional length of each main lap, if laps have different length. Overrides LapLen.
+	LapLens []uint32 `json:"lapLens,omitempty"`
 	// PenaltyLen - length of each penalty lap.
 	PenaltyLen uint32 `json:"penaltyLen"`
 	// FiringLines - number of firing lines in race.
 	FiringLines uint32 `json:"firingLines"`
+	// FiringLineLaps - optional number of lap, which ends with firing line, for each firing line.
+	// Firing lines are numbered from 1 in the same way as in CompetitorOnFiringRange event.
+	FiringLineLaps []uint32 `json:"firingLineLaps,omitempty"`
+	// Targets - number of targets on each firing line. Default is DefaultTargets.
+	Targets uint32 `json:"targets,omitempty"`
+	// FiringLineTargets - optional number of targets on each firing line, if firing lines
+	// have different number of targets. Overrides Targets.
+	FiringLineTargets []uint32 `json:"firingLineTargets,omitempty"`
+	// FiringLinePositions - optional shooting position on each firing line: PositionProne or PositionStanding.
+	// If set, accuracy and range time are reported for each position.
+	FiringLinePositions []string `json:"firingLinePositions,omitempty"`
+	// PullLapped - if true, competitors lapped by the leader are pulled out of the race.
+	// Usually used in mass start and pursuit races.
+	PullLapped bool `json:"pullLapped,omitempty"`
 	// Start - planned start time for the first competitor.
 	Start string `json:"start"`
 	// StartDelta - planned interval between starts
 	StartDelta string `json:"startDelta"`
+	// Categories - optional list of categories, each of them is ranked separately in report.
+	Categories []string `json:"categories,omitempty"`
+	// Competitors - optional registry of competitors by competitorID.
+	Competitors map[string]Competitor `json:"competitors,omitempty"`
+	// Teams - optional settings of team ranking. If not set, team ranking is not created.
+	Teams *TeamRanking `json:"teams,omitempty"`
+}
+
+// LapLengths returns length of each main lap.
+func (conf BiathlonCompetition) LapLengths() []uint32 {
+	if len(conf.LapLens) != 0 {
+		return conf.LapLens
+	}
+
+	lapLens := make([]uint32, conf.Laps)
+	for i := range lapLens {
+		lapLens[i] = conf.LapLen
+	}
+
+	return lapLens
+}
+
+// TargetsPerFiringLine returns number of targets on each firing line.
+func (conf BiathlonCompetition) TargetsPerFiringLine() []uint32 {
+	if len(conf.FiringLineTargets) != 0 {
+		return conf.FiringLineTargets
+	}
+
+	targets := conf.Targets
+	if targets == 0 {
+		targets = DefaultTargets
+	}
+
+	perFiringLine := make([]uint32, conf.FiringLines)
+	for i := range perFiringLine {
+		perFiringLine[i] = targets
+	}
+
+	return perFiringLine
+}
+
+// Shooting positions on firing line.
+const (
+	PositionProne    = "prone"
+	PositionStanding = "standing"
+)
+
+// Competitor represents registry entry with information about single competitor.
+type Competitor struct {
+	// Category of the competitor (e.g. men, women, youth).
+	Category string `json:"category,omitempty"`
+	// Team (club or nation) of the competitor.
+	Team string `json:"team,omitempty"`
+}
+
+// Possible tie-break rules for team ranking.
+const (
+	// TieBreakBestIndividual - team with the fastest counted competitor is ranked higher.
+	TieBreakBestIndividual = "bestIndividual"
+	// TieBreakLastCounting - team with the fastest last counted competitor is ranked higher.
+	TieBreakLastCounting = "lastCounting"
+)
+
+// TeamRanking represents settings of team ranking.
+type TeamRanking struct {
+	// BestN - amount of best finishers of each team, whose total times are summed.
+	BestN uint32 `json:"bestN"`
+	// TieBreak - rule to resolve equal team times. Default is TieBreakBestIndividual.
+	TieBreak string `json:"tieBreak,omitempty"`
 }
 
-// Read given configFileName and fill config.
+// Read given configFileName and fill config. Format of config is determined by file extension
+// (see FormatFromPath). If config implements EnvOverrider, values from environment variables
+// replace values from file.
+// All found unknown fields and, if config implements Validator, invalid values
+// are returned at once in single error.
 func Read(configFileName string, config interface{}) error {
+	format := FormatFromPath(configFileName)
+
 	bytes, err := os.ReadFile(configFileName)
 	if err != nil {
 		return err
 	}
 
+	bytes, err = toJSON(bytes, format)
+	if err != nil {
+		return fmt.Errorf("failed to parse config file: %s, err: %w", configFileName, err)
+	}
+
+	var raw map[string]any
+	err = json.Unmarshal(bytes, &raw)
+	if err != nil {
+		return fmt.Errorf("failed to parse config file: %s, err: %w", configFileName, err)
+	}
+
+	errs := make([]error, 0)
+	if overrider, ok := config.(EnvOverrider); ok {
+		errs = append(errs, overrideFromEnv(raw, reflect.TypeOf(config), overrider.EnvPrefix(), os.LookupEnv)...)
+		bytes, _ = json.Marshal(raw)
+	}
+
 	err = json.Unmarshal(bytes, config)
 	if err != nil {
 		return fmt.Errorf("failed to parse config file: %s, err: %w", configFileName, err)
 	}
 
-	return nil
-}
+	errs = append(errs, unknownFields(raw, reflect.TypeOf(config), "")...)
+	if validator, ok := config.(Validator); ok {
+		errs = append(errs, validator.Validate())
+	}
 
-// Print given config to stdout.
-func Print(config interface{}) {
-	bytes, _ := json.Marshal(config)
-	fmt.Println(string(bytes))
+	err = errors.Join(errs...)
+	if err != nil {
+		return fmt.Errorf("invalid config file: %s\n%w", configFileName, err)
+	}
+
+	return nil
 }
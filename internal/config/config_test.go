@@ -0,0 +1,253 @@
+package config
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func fieldErrors(err error) []FieldError {
+	var joined interface{ Unwrap() []error }
+	if !errors.As(err, &joined) {
+		return nil
+	}
+
+	res := make([]FieldError, 0)
+	for _, e := range joined.Unwrap() {
+		var fieldErr *FieldError
+		if errors.As(e, &fieldErr) {
+			res = append(res, *fieldErr)
+		} else {
+			res = append(res, fieldErrors(e)...)
+		}
+	}
+
+	return res
+}
+
+func Test_BiathlonCompetition_Validate(t *testing.T) {
+	validConf := BiathlonCompetition{
+		Laps:        2,
+		LapLen:      3500,
+		PenaltyLen:  150,
+		FiringLines: 2,
+		Start:       "10:00:00.000",
+		StartDelta:  "00:01:30",
+	}
+
+	t.Run("with valid config", func(t *testing.T) {
+		assert.Nil(t, validConf.Validate())
+	})
+
+	t.Run("with valid lap lengths, firing line laps and positions", func(t *testing.T) {
+		conf := validConf
+		conf.LapLen = 0
+		conf.Laps = 3
+		conf.LapLens = []uint32{3000, 3500, 2500}
+		conf.FiringLineLaps = []uint32{1, 3}
+		conf.FiringLinePositions = []string{"prone", "standing"}
+
+		assert.Nil(t, conf.Validate())
+		assert.Equal(t, []uint32{3000, 3500, 2500}, conf.LapLengths())
+		assert.Equal(t, []uint32{3500, 3500}, validConf.LapLengths())
+	})
+
+	t.Run("with invalid lap lengths, firing line laps and positions", func(t *testing.T) {
+		conf := validConf
+		conf.Laps = 3
+		conf.FiringLines = 3
+		conf.LapLens = []uint32{3000, 0}
+		conf.FiringLineLaps = []uint32{2, 2, 4}
+		conf.FiringLinePositions = []string{"prone", "kneeling"}
+
+		paths := make([]string, 0)
+		for _, fieldErr := range fieldErrors(conf.Validate()) {
+			paths = append(paths, fieldErr.Path)
+		}
+
+		assert.Equal(t, []string{
+			"lapLens",
+			"lapLens.1",
+			"firingLineLaps.1",
+			"firingLineLaps.2",
+			"firingLinePositions",
+			"firingLinePositions.1",
+		}, paths)
+	})
+
+	t.Run("with invalid config", func(t *testing.T) {
+		conf := BiathlonCompetition{
+			FiringLines: 1,
+			Start:       "10h",
+			Categories:  []string{"men", "", "men"},
+			Competitors: map[string]Competitor{
+				"1": {Category: "men"},
+				"2": {Category: "youth"},
+			},
+			Teams: &TeamRanking{TieBreak: "random"},
+		}
+
+		paths := make([]string, 0)
+		for _, fieldErr := range fieldErrors(conf.Validate()) {
+			paths = append(paths, fieldErr.Path)
+		}
+
+		assert.Equal(t, []string{
+			"laps",
+			"lapLen",
+			"firingLines",
+			"start",
+			"startDelta",
+			"categories.1",
+			"categories.2",
+			"competitors.2.category",
+			"teams.tieBreak",
+		}, paths)
+	})
+}
+
+func Test_Cup_Validate(t *testing.T) {
+	assert.Nil(t, Cup{Points: []uint32{10}}.Validate())
+
+	errs := fieldErrors(Cup{TieBreak: "random"}.Validate())
+	assert.Len(t, errs, 2)
+	assert.ErrorIs(t, errs[0].Err, ErrRequired)
+	assert.ErrorIs(t, errs[1].Err, ErrUnknownValue)
+}
+
+func Test_Read(t *testing.T) {
+	writeConfig := func(t *testing.T, content string) string {
+		path := filepath.Join(t.TempDir(), "config.json")
+		assert.Nil(t, os.WriteFile(path, []byte(content), 0o600))
+
+		return path
+	}
+
+	t.Run("with valid config", func(t *testing.T) {
+		path := writeConfig(t, `{"Laps": 2, "lapLen": 3500, "firingLines": 1, "start": "09:30:00", "startDelta": "00:00:30",
+			"competitors": {"1": {"team": "RUS"}}}`)
+
+		conf := BiathlonCompetition{}
+		err := Read(path, &conf)
+
+		assert.Nil(t, err)
+		assert.Equal(t, uint32(2), conf.Laps)
+		assert.Equal(t, "RUS", conf.Competitors["1"].Team)
+	})
+
+	t.Run("with unknown fields and invalid values", func(t *testing.T) {
+		path := writeConfig(t, `{"laps": 2, "lapLen": 0, "start": "09:30:00", "startDelta": "00:00:30",
+			"lapLength": 10, "competitors": {"1": {"team": "RUS", "nation": "RUS"}}}`)
+
+		conf := BiathlonCompetition{}
+		err := Read(path, &conf)
+
+		errs := fieldErrors(errors.Unwrap(err))
+		assert.Len(t, errs, 3)
+		assert.Equal(t, FieldError{Path: "competitors.1.nation", Err: ErrUnknownField}, errs[0])
+		assert.Equal(t, FieldError{Path: "lapLength", Err: ErrUnknownField}, errs[1])
+		assert.Equal(t, FieldError{Path: "lapLen", Err: ErrMustBePositive}, errs[2])
+	})
+
+	t.Run("with unparseable config", func(t *testing.T) {
+		path := writeConfig(t, `{"laps": "two"}`)
+
+		err := Read(path, &BiathlonCompetition{})
+
+		assert.NotNil(t, err)
+		assert.Empty(t, fieldErrors(err))
+	})
+}
+
+func Test_Read_Formats(t *testing.T) {
+	expected := BiathlonCompetition{
+		Laps:        2,
+		LapLen:      3500,
+		PenaltyLen:  150,
+		FiringLines: 2,
+		Start:       "10:00:00.000",
+		StartDelta:  "00:01:30",
+		Competitors: map[string]Competitor{"1": {Team: "RUS"}},
+	}
+
+	contents := map[string]string{
+		"config.json": `{"laps": 2, "lapLen": 3500, "penaltyLen": 150, "firingLines": 2,
+			"start": "10:00:00.000", "startDelta": "00:01:30", "competitors": {"1": {"team": "RUS"}}}`,
+		"config.yaml": "laps: 2\nlapLen: 3500\npenaltyLen: 150\nfiringLines: 2\n" +
+			"start: \"10:00:00.000\"\nstartDelta: \"00:01:30\"\ncompetitors:\n  1:\n    team: RUS\n",
+		"config": `{"laps": 2, "lapLen": 3500, "penaltyLen": 150, "firingLines": 2,
+			"start": "10:00:00.000", "startDelta": "00:01:30", "competitors": {"1": {"team": "RUS"}}}`,
+	}
+
+	for name, content := range contents {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), name)
+			assert.Nil(t, os.WriteFile(path, []byte(content), 0o600))
+
+			conf := BiathlonCompetition{}
+			err := Read(path, &conf)
+
+			assert.Nil(t, err)
+			assert.Equal(t, expected, conf)
+
+			printed := bytes.Buffer{}
+			assert.Nil(t, Print(&printed, conf, FormatFromPath(path)))
+
+			printedPath := filepath.Join(t.TempDir(), name)
+			assert.Nil(t, os.WriteFile(printedPath, printed.Bytes(), 0o600))
+
+			printedConf := BiathlonCompetition{}
+			assert.Nil(t, Read(printedPath, &printedConf))
+			assert.Equal(t, expected, printedConf)
+		})
+	}
+
+	t.Run("with unknown extension", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "config.ini")
+		assert.Nil(t, os.WriteFile(path, []byte("laps: 2\n"), 0o600))
+
+		err := Read(path, &BiathlonCompetition{})
+
+		assert.NotNil(t, err, "config with unknown extension is read as JSON")
+	})
+}
+
+func Test_Read_EnvOverrides(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yml")
+	assert.Nil(t, os.WriteFile(path, []byte("laps: 1\nlapLen: 3500\nstart: 10:00:00\nstartDelta: 00:01:30\n"), 0o600))
+
+	t.Setenv("BIATHLON_LAPS", "3")
+	t.Setenv("BIATHLON_FIRING_LINES", "2")
+	t.Setenv("BIATHLON_START_DELTA", "00:00:30")
+	t.Setenv("BIATHLON_CATEGORIES", "men, women")
+	t.Setenv("BIATHLON_TEAMS", `{"bestN": 3}`)
+
+	conf := BiathlonCompetition{}
+	err := Read(path, &conf)
+
+	assert.Nil(t, err)
+	assert.Equal(t, BiathlonCompetition{
+		Laps:        3,
+		LapLen:      3500,
+		FiringLines: 2,
+		Start:       "10:00:00",
+		StartDelta:  "00:00:30",
+		Categories:  []string{"men", "women"},
+		Teams:       &TeamRanking{BestN: 3},
+	}, conf)
+
+	t.Setenv("BIATHLON_LAP_LEN", "long")
+
+	errs := fieldErrors(errors.Unwrap(Read(path, &BiathlonCompetition{})))
+	assert.Len(t, errs, 1)
+	assert.Equal(t, "BIATHLON_LAP_LEN", errs[0].Path)
+}
+
+func Test_EnvName(t *testing.T) {
+	assert.Equal(t, "BIATHLON_LAPS", EnvName(EnvPrefix, "laps"))
+	assert.Equal(t, "BIATHLON_START_DELTA", EnvName(EnvPrefix, "startDelta"))
+}
@@ -0,0 +1,23 @@
+package config
+
+// Possible tie-break rules for cup standings.
+const (
+	// TieBreakCountBack - competitor with more first places is ranked higher,
+	// then competitor with more second places and so on.
+	TieBreakCountBack = "countBack"
+	// TieBreakLastRace - competitor with better result in the latest race is ranked higher,
+	// then in the previous race and so on.
+	TieBreakLastRace = "lastRace"
+)
+
+// Cup represents config for season cup standings.
+type Cup struct {
+	// Points - points for places, starting from the first place. Places after the last one get zero points.
+	Points []uint32 `json:"points"`
+	// DropWorst - amount of worst results of each competitor, that are not counted.
+	DropWorst uint32 `json:"dropWorst,omitempty"`
+	// TieBreak - rule to resolve equal points. Default is TieBreakCountBack.
+	TieBreak string `json:"tieBreak,omitempty"`
+	// Category - optional category, if set standings are calculated with category rankings of races.
+	Category string `json:"category,omitempty"`
+}
@@ -0,0 +1,100 @@
+package config
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"unicode"
+)
+
+// EnvPrefix is the prefix of environment variables, that override fields of BiathlonCompetition.
+const EnvPrefix = "BIATHLON_"
+
+// EnvOverrider is implemented by configs, which fields can be overridden with environment variables.
+type EnvOverrider interface {
+	// EnvPrefix returns prefix of environment variables for this config.
+	EnvPrefix() string
+}
+
+// EnvPrefix implements EnvOverrider. Each field can be overridden with variable named as
+// EnvPrefix followed by the name of field in upper snake case, e.g. BIATHLON_LAP_LEN.
+func (conf BiathlonCompetition) EnvPrefix() string {
+	return EnvPrefix
+}
+
+// EnvName returns the name of environment variable for the field with given name in JSON.
+func EnvName(prefix string, field string) string {
+	builder := strings.Builder{}
+	_, _ = builder.WriteString(prefix)
+
+	for i, r := range field {
+		if i > 0 && unicode.IsUpper(r) {
+			_, _ = builder.WriteRune('_')
+		}
+
+		_, _ = builder.WriteRune(unicode.ToUpper(r))
+	}
+
+	return builder.String()
+}
+
+// overrideFromEnv replaces values of top-level fields in decoded JSON object with values
+// of environment variables. Strings are taken as is, comma separated values are allowed for
+// lists of strings, other values must be in JSON.
+func overrideFromEnv(raw map[string]any, t reflect.Type, prefix string, lookupEnv func(string) (string, bool)) []error {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	errs := make([]error, 0)
+
+	fields := jsonFields(t)
+	for _, name := range sortedKeys(fields) {
+		envName := EnvName(prefix, name)
+
+		envValue, ok := lookupEnv(envName)
+		if !ok {
+			continue
+		}
+
+		value, err := parseEnvValue(envValue, fields[name].Type)
+		if err != nil {
+			errs = append(errs, &FieldError{Path: envName, Err: err})
+			continue
+		}
+
+		for key := range raw {
+			if strings.EqualFold(key, name) {
+				delete(raw, key)
+			}
+		}
+
+		raw[name] = value
+	}
+
+	return errs
+}
+
+// parseEnvValue parses value of environment variable for the field of given type.
+func parseEnvValue(value string, fieldType reflect.Type) (any, error) {
+	if fieldType.Kind() == reflect.String {
+		return value, nil
+	}
+
+	var parsed any
+	if err := json.Unmarshal([]byte(value), &parsed); err == nil {
+		return parsed, nil
+	}
+
+	if fieldType.Kind() == reflect.Slice && fieldType.Elem().Kind() == reflect.String {
+		items := make([]any, 0)
+		for item := range strings.SplitSeq(value, ",") {
+			items = append(items, strings.TrimSpace(item))
+		}
+
+		return items, nil
+	}
+
+	return nil, fmt.Errorf("bad value: %s, expected value in json", value)
+}
@@ -0,0 +1,156 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format of config file.
+type Format string
+
+// constants define supported config formats.
+const (
+	FormatJSON Format = "json"
+	FormatYAML Format = "yaml"
+)
+
+// ParseFormat checks if the given string is a supported config format.
+func ParseFormat(s string) (Format, error) {
+	switch format := Format(s); format {
+	case FormatJSON, FormatYAML:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unsupported config format: %s", s)
+	}
+}
+
+// FormatFromPath determines config format by extension of the file.
+// Files with other extensions or without extension are considered to be in JSON.
+func FormatFromPath(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		return FormatYAML
+	default:
+		return FormatJSON
+	}
+}
+
+// toJSON converts config in given format to JSON, so all formats are decoded with the same rules.
+func toJSON(data []byte, format Format) ([]byte, error) {
+	var (
+		raw any
+		err error
+	)
+
+	switch format {
+	case FormatJSON:
+		return data, nil
+	case FormatYAML:
+		err = yaml.Unmarshal(data, &raw)
+	default:
+		return nil, fmt.Errorf("unsupported config format: %s", format)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if raw == nil {
+		raw = map[string]any{}
+	}
+
+	return json.Marshal(stringKeys(raw))
+}
+
+// stringKeys converts keys of all maps to strings, as YAML allows keys of any type
+// (e.g. competitor id 1 is decoded as integer).
+func stringKeys(value any) any {
+	switch typed := value.(type) {
+	case map[any]any:
+		converted := make(map[string]any, len(typed))
+		for key, elem := range typed {
+			converted[fmt.Sprint(key)] = stringKeys(elem)
+		}
+
+		return converted
+	case map[string]any:
+		for key, elem := range typed {
+			typed[key] = stringKeys(elem)
+		}
+	case []any:
+		for i, elem := range typed {
+			typed[i] = stringKeys(elem)
+		}
+	}
+
+	return value
+}
+
+// fromJSON converts config in JSON to given format. Keys of objects are sorted.
+func fromJSON(data []byte, format Format) ([]byte, error) {
+	if format == FormatJSON {
+		return data, nil
+	}
+
+	if format != FormatYAML {
+		return nil, fmt.Errorf("unsupported config format: %s", format)
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var raw any
+	if err := decoder.Decode(&raw); err != nil {
+		return nil, err
+	}
+
+	return yaml.Marshal(normalizeNumbers(raw))
+}
+
+// normalizeNumbers replaces json.Number with int64 or float64,
+// so numbers are not encoded as strings.
+func normalizeNumbers(value any) any {
+	switch typed := value.(type) {
+	case json.Number:
+		if i, err := typed.Int64(); err == nil {
+			return i
+		}
+
+		f, _ := typed.Float64()
+
+		return f
+	case map[string]any:
+		for key, elem := range typed {
+			typed[key] = normalizeNumbers(elem)
+		}
+	case []any:
+		for i, elem := range typed {
+			typed[i] = normalizeNumbers(elem)
+		}
+	}
+
+	return value
+}
+
+// Print given config to w in given format.
+func Print(w io.Writer, config interface{}, format Format) error {
+	data, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	data, err = fromJSON(data, format)
+	if err != nil {
+		return fmt.Errorf("failed to convert config to %s: %w", format, err)
+	}
+
+	_, err = fmt.Fprintln(w, strings.TrimRight(string(data), "\n"))
+
+	return err
+}
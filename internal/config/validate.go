@@ -0,0 +1,311 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Errors returned in FieldError.
+var (
+	ErrUnknownField    = errors.New("unknown field")
+	ErrRequired        = errors.New("required")
+	ErrMustBePositive  = errors.New("must be greater than zero")
+	ErrBadTime         = errors.New("must be time in format HH:MM:SS[.sss]")
+	ErrUnknownValue    = errors.New("unknown value")
+	ErrDuplicatedValue = errors.New("duplicated value")
+)
+
+// FieldError describes a problem with single field of config.
+type FieldError struct {
+	// Path to the field, e.g. "laps" or "competitors.1.category".
+	Path string
+	Err  error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Path, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// Validator is implemented by configs, that can check their values.
+type Validator interface {
+	// Validate returns all found problems joined into single error.
+	Validate() error
+}
+
+// Validate checks values of BiathlonCompetition.
+func (conf BiathlonCompetition) Validate() error {
+	errs := make([]error, 0)
+
+	if conf.Laps == 0 {
+		errs = append(errs, &FieldError{Path: "laps", Err: ErrMustBePositive})
+	}
+
+	if conf.LapLen == 0 && len(conf.LapLens) == 0 {
+		errs = append(errs, &FieldError{Path: "lapLen", Err: ErrMustBePositive})
+	}
+
+	if len(conf.LapLens) != 0 && len(conf.LapLens) != int(conf.Laps) {
+		errs = append(errs, &FieldError{
+			Path: "lapLens",
+			Err:  fmt.Errorf("must contain length of each lap (%d), got: %d", conf.Laps, len(conf.LapLens)),
+		})
+	}
+
+	for i, lapLen := range conf.LapLens {
+		if lapLen == 0 {
+			errs = append(errs, &FieldError{Path: fmt.Sprintf("lapLens.%d", i), Err: ErrMustBePositive})
+		}
+	}
+
+	if conf.FiringLines > conf.Laps {
+		errs = append(errs, &FieldError{
+			Path: "firingLines",
+			Err:  fmt.Errorf("must not be greater than laps (%d)", conf.Laps),
+		})
+	}
+
+	errs = append(errs, conf.validateFiringLineLaps()...)
+
+	if len(conf.FiringLineTargets) != 0 && len(conf.FiringLineTargets) != int(conf.FiringLines) {
+		errs = append(errs, &FieldError{
+			Path: "firingLineTargets",
+			Err:  fmt.Errorf("must contain number of targets of each firing line (%d), got: %d", conf.FiringLines, len(conf.FiringLineTargets)),
+		})
+	}
+
+	for i, targets := range conf.FiringLineTargets {
+		if targets == 0 || targets > MaxTargets {
+			errs = append(errs, &FieldError{Path: fmt.Sprintf("firingLineTargets.%d", i), Err: errBadTargets(targets)})
+		}
+	}
+
+	if conf.Targets > MaxTargets {
+		errs = append(errs, &FieldError{Path: "targets", Err: errBadTargets(conf.Targets)})
+	}
+
+	if len(conf.FiringLinePositions) != 0 && len(conf.FiringLinePositions) != int(conf.FiringLines) {
+		errs = append(errs, &FieldError{
+			Path: "firingLinePositions",
+			Err:  fmt.Errorf("must contain position of each firing line (%d), got: %d", conf.FiringLines, len(conf.FiringLinePositions)),
+		})
+	}
+
+	for i, position := range conf.FiringLinePositions {
+		path := fmt.Sprintf("firingLinePositions.%d", i)
+
+		if position == "" {
+			errs = append(errs, &FieldError{Path: path, Err: ErrRequired})
+		} else {
+			errs = append(errs, validateOneOf(path, position, PositionProne, PositionStanding)...)
+		}
+	}
+
+	errs = append(errs, validateTime("start", conf.Start)...)
+	errs = append(errs, validateTime("startDelta", conf.StartDelta)...)
+
+	for i, category := range conf.Categories {
+		path := fmt.Sprintf("categories.%d", i)
+
+		if category == "" {
+			errs = append(errs, &FieldError{Path: path, Err: ErrRequired})
+		} else if slices.Index(conf.Categories, category) != i {
+			errs = append(errs, &FieldError{Path: path, Err: fmt.Errorf("%w: %s", ErrDuplicatedValue, category)})
+		}
+	}
+
+	for _, competitorID := range sortedKeys(conf.Competitors) {
+		category := conf.Competitors[competitorID].Category
+		if category != "" && len(conf.Categories) > 0 && !slices.Contains(conf.Categories, category) {
+			errs = append(errs, &FieldError{
+				Path: "competitors." + competitorID + ".category",
+				Err:  fmt.Errorf("%w: %s, expected one of categories", ErrUnknownValue, category),
+			})
+		}
+	}
+
+	if conf.Teams != nil {
+		errs = append(errs, validateOneOf("teams.tieBreak", conf.Teams.TieBreak, TieBreakBestIndividual, TieBreakLastCounting)...)
+	}
+
+	return errors.Join(errs...)
+}
+
+// validateFiringLineLaps checks that each firing line is placed at the end of existing lap
+// and firing lines are placed in order of laps.
+func (conf BiathlonCompetition) validateFiringLineLaps() []error {
+	errs := make([]error, 0)
+
+	if len(conf.FiringLineLaps) != 0 && len(conf.FiringLineLaps) != int(conf.FiringLines) {
+		errs = append(errs, &FieldError{
+			Path: "firingLineLaps",
+			Err:  fmt.Errorf("must contain lap of each firing line (%d), got: %d", conf.FiringLines, len(conf.FiringLineLaps)),
+		})
+	}
+
+	for i, lap := range conf.FiringLineLaps {
+		path := fmt.Sprintf("firingLineLaps.%d", i)
+
+		if lap == 0 || lap > conf.Laps {
+			errs = append(errs, &FieldError{Path: path, Err: fmt.Errorf("must be lap number from 1 to %d, got: %d", conf.Laps, lap)})
+		} else if i > 0 && lap <= conf.FiringLineLaps[i-1] {
+			errs = append(errs, &FieldError{Path: path, Err: fmt.Errorf("must be greater than lap of previous firing line, got: %d", lap)})
+		}
+	}
+
+	return errs
+}
+
+func errBadTargets(targets uint32) error {
+	return fmt.Errorf("must be number of targets from 1 to %d, got: %d", MaxTargets, targets)
+}
+
+// Validate checks values of Cup.
+func (conf Cup) Validate() error {
+	errs := make([]error, 0)
+
+	if len(conf.Points) == 0 {
+		errs = append(errs, &FieldError{Path: "points", Err: ErrRequired})
+	}
+
+	errs = append(errs, validateOneOf("tieBreak", conf.TieBreak, TieBreakCountBack, TieBreakLastRace)...)
+
+	return errors.Join(errs...)
+}
+
+func validateTime(path string, value string) []error {
+	if value == "" {
+		return []error{&FieldError{Path: path, Err: ErrRequired}}
+	}
+
+	if _, err := time.Parse(time.TimeOnly, value); err != nil {
+		return []error{&FieldError{Path: path, Err: fmt.Errorf("%w, got: %s", ErrBadTime, value)}}
+	}
+
+	return nil
+}
+
+// validateOneOf checks that optional value is one of allowed.
+func validateOneOf(path string, value string, allowed ...string) []error {
+	if value == "" || slices.Contains(allowed, value) {
+		return nil
+	}
+
+	return []error{&FieldError{
+		Path: path,
+		Err:  fmt.Errorf("%w: %s, expected one of: %s", ErrUnknownValue, value, strings.Join(allowed, ", ")),
+	}}
+}
+
+// unknownFields compares decoded JSON value with fields of given type and returns
+// FieldError for each key, that does not correspond to any field.
+func unknownFields(value any, t reflect.Type, path string) []error {
+	for t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+
+	errs := make([]error, 0)
+
+	switch typed := value.(type) {
+	case map[string]any:
+		if t.Kind() == reflect.Map {
+			for _, key := range sortedKeys(typed) {
+				errs = append(errs, unknownFields(typed[key], t.Elem(), joinPath(path, key))...)
+			}
+
+			return errs
+		}
+
+		if t.Kind() != reflect.Struct {
+			return nil
+		}
+
+		fields := jsonFields(t)
+		for _, key := range sortedKeys(typed) {
+			field, ok := lookupField(fields, key)
+			if !ok {
+				errs = append(errs, &FieldError{Path: joinPath(path, key), Err: ErrUnknownField})
+				continue
+			}
+
+			errs = append(errs, unknownFields(typed[key], field.Type, joinPath(path, key))...)
+		}
+	case []any:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return nil
+		}
+
+		for i, elem := range typed {
+			errs = append(errs, unknownFields(elem, t.Elem(), joinPath(path, fmt.Sprint(i)))...)
+		}
+	}
+
+	return errs
+}
+
+// jsonFields returns fields of struct type by their names in JSON.
+func jsonFields(t reflect.Type) map[string]reflect.StructField {
+	fields := make(map[string]reflect.StructField, t.NumField())
+
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		fields[name] = field
+	}
+
+	return fields
+}
+
+// lookupField finds field by key the same way encoding/json does,
+// preferring an exact match to a case-insensitive one.
+func lookupField(fields map[string]reflect.StructField, key string) (reflect.StructField, bool) {
+	if field, ok := fields[key]; ok {
+		return field, true
+	}
+
+	for name, field := range fields {
+		if strings.EqualFold(name, key) {
+			return field, true
+		}
+	}
+
+	return reflect.StructField{}, false
+}
+
+func joinPath(path string, key string) string {
+	if path == "" {
+		return key
+	}
+
+	return path + "." + key
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
@@ -0,0 +1,226 @@
+// cup contains types and functions need to calculate season cup standings
+// from several race reports.
+package cup
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/config"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/report"
+)
+
+// Race is a single race of the season.
+type Race struct {
+	// Name of the race.
+	Name string
+	// Results of the race.
+	Results []report.JSONResult
+}
+
+// ReadRace reads Race from report written in report.FormatJSON.
+// If category is not empty, results are taken from the ranking of this category.
+func ReadRace(name string, r io.Reader, category string) (Race, error) {
+	doc := report.JSONDocument{}
+
+	err := json.NewDecoder(r).Decode(&doc)
+	if err != nil {
+		return Race{}, fmt.Errorf("failed to decode race report: %s, err: %w", name, err)
+	}
+
+	if category == "" {
+		return Race{Name: name, Results: doc.Results}, nil
+	}
+
+	for _, ranking := range doc.Rankings {
+		if ranking.Title == category {
+			return Race{Name: name, Results: ranking.Results}, nil
+		}
+	}
+
+	return Race{}, fmt.Errorf("no ranking for category '%s' in race report: %s", category, name)
+}
+
+// raceResult is the result of single competitor in single race.
+type raceResult struct {
+	// place is zero if competitor is not ranked.
+	place int
+	// status is empty if competitor did not take part in the race.
+	status  report.Status
+	points  uint32
+	dropped bool
+}
+
+func (rr raceResult) String() string {
+	value := "-"
+
+	switch {
+	case rr.place != 0:
+		value = fmt.Sprint(rr.points)
+	case rr.status != "":
+		value = string(rr.status)
+	}
+
+	if rr.dropped {
+		return "(" + value + ")"
+	}
+
+	return value
+}
+
+type standing struct {
+	place        int
+	competitorID string
+	points       uint32
+	results      []raceResult
+}
+
+// Standings are cup standings of competitors, sorted by place.
+type Standings []standing
+
+// Calculate Standings for the given races. Races must be in chronological order.
+//
+// Competitors get points from conf.Points according to their place in each race.
+// Not ranked competitors (not started, not finished, disqualified) and competitors
+// absent in race get zero points. conf.DropWorst worst results of each competitor
+// are not counted.
+func Calculate(conf config.Cup, races []Race) Standings {
+	byCompetitor := make(map[string][]raceResult)
+
+	for i, race := range races {
+		for _, result := range race.Results {
+			results, ok := byCompetitor[result.CompetitorID]
+			if !ok {
+				results = make([]raceResult, len(races))
+				byCompetitor[result.CompetitorID] = results
+			}
+
+			results[i] = raceResult{
+				place:  result.Place,
+				status: result.Status,
+			}
+
+			if result.Place > 0 && result.Place <= len(conf.Points) {
+				results[i].points = conf.Points[result.Place-1]
+			}
+		}
+	}
+
+	standings := make(Standings, 0, len(byCompetitor))
+	for competitorID, results := range byCompetitor {
+		dropWorst(results, conf.DropWorst)
+
+		s := standing{
+			competitorID: competitorID,
+			results:      results,
+		}
+
+		for _, result := range results {
+			if !result.dropped {
+				s.points += result.points
+			}
+		}
+
+		standings = append(standings, s)
+	}
+
+	slices.SortFunc(standings, func(first, second standing) int {
+		if res := compareStandings(first, second, conf.TieBreak); res != 0 {
+			return res
+		}
+
+		return strings.Compare(first.competitorID, second.competitorID)
+	})
+
+	for i := range standings {
+		standings[i].place = i + 1
+		if i > 0 && compareStandings(standings[i-1], standings[i], conf.TieBreak) == 0 {
+			standings[i].place = standings[i-1].place
+		}
+	}
+
+	return standings
+}
+
+// dropWorst marks count of results with the least points as dropped.
+// If results have equal points, the earliest one is dropped.
+func dropWorst(results []raceResult, count uint32) {
+	indexes := make([]int, len(results))
+	for i := range indexes {
+		indexes[i] = i
+	}
+
+	slices.SortStableFunc(indexes, func(first, second int) int {
+		return cmp.Compare(results[first].points, results[second].points)
+	})
+
+	for _, i := range indexes[:min(int(count), len(indexes))] {
+		results[i].dropped = true
+	}
+}
+
+func compareStandings(first, second standing, tieBreak string) int {
+	if res := cmp.Compare(second.points, first.points); res != 0 {
+		return res
+	}
+
+	if tieBreak == config.TieBreakLastRace {
+		for i := len(first.results) - 1; i >= 0; i-- {
+			if res := comparePlaces(first.results[i].place, second.results[i].place); res != 0 {
+				return res
+			}
+		}
+
+		return 0
+	}
+
+	firstPlaces, secondPlaces := placesCount(first.results), placesCount(second.results)
+	for place := 1; place <= max(maxPlace(first.results), maxPlace(second.results)); place++ {
+		if res := cmp.Compare(secondPlaces[place], firstPlaces[place]); res != 0 {
+			return res
+		}
+	}
+
+	return 0
+}
+
+// comparePlaces compares places in single race. Ranked competitor is better than not ranked one.
+func comparePlaces(first, second int) int {
+	if first == second {
+		return 0
+	}
+
+	if first == 0 {
+		return 1
+	}
+
+	if second == 0 {
+		return -1
+	}
+
+	return cmp.Compare(first, second)
+}
+
+func placesCount(results []raceResult) map[int]int {
+	count := make(map[int]int)
+	for _, result := range results {
+		if result.place != 0 {
+			count[result.place] += 1
+		}
+	}
+
+	return count
+}
+
+func maxPlace(results []raceResult) int {
+	res := 0
+	for _, result := range results {
+		res = max(res, result.place)
+	}
+
+	return res
+}
@@ -0,0 +1,135 @@
+package cup
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/config"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/report"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ReadRace(t *testing.T) {
+	givenReport := `{
+		"results": [
+			{"place": 1, "competitorId": "1", "status": "Finished"},
+			{"competitorId": "2", "status": "NotFinished"}
+		],
+		"rankings": [
+			{"title": "men", "results": [{"place": 1, "competitorId": "1", "status": "Finished"}]}
+		]
+	}`
+
+	t.Run("without category", func(t *testing.T) {
+		race, err := ReadRace("race", strings.NewReader(givenReport), "")
+
+		assert.Nil(t, err)
+		assert.Equal(t, "race", race.Name)
+		assert.Len(t, race.Results, 2)
+	})
+
+	t.Run("with category", func(t *testing.T) {
+		race, err := ReadRace("race", strings.NewReader(givenReport), "men")
+
+		assert.Nil(t, err)
+		assert.Equal(t, []report.JSONResult{{Place: 1, CompetitorID: "1", Status: report.StatusFinished}}, race.Results)
+	})
+
+	t.Run("with unknown category", func(t *testing.T) {
+		_, err := ReadRace("race", strings.NewReader(givenReport), "women")
+
+		assert.NotNil(t, err)
+	})
+
+	t.Run("with bad report", func(t *testing.T) {
+		_, err := ReadRace("race", strings.NewReader("hello"), "")
+
+		assert.NotNil(t, err)
+	})
+}
+
+func Test_Calculate(t *testing.T) {
+	finished := func(id string, place int) report.JSONResult {
+		return report.JSONResult{Place: place, CompetitorID: id, Status: report.StatusFinished}
+	}
+
+	races := []Race{
+		{
+			Name: "first",
+			Results: []report.JSONResult{
+				finished("1", 1),
+				finished("2", 2),
+				finished("3", 3),
+				{CompetitorID: "4", Status: report.StatusDNS},
+			},
+		},
+		{
+			Name: "second",
+			Results: []report.JSONResult{
+				finished("3", 1),
+				finished("1", 2),
+				{CompetitorID: "2", Status: report.StatusDNF},
+			},
+		},
+		{
+			Name: "third",
+			Results: []report.JSONResult{
+				finished("2", 1),
+				finished("3", 2),
+				finished("4", 3),
+				finished("1", 4),
+			},
+		},
+	}
+
+	t.Run("without dropped results", func(t *testing.T) {
+		standings := Calculate(config.Cup{Points: []uint32{10, 6, 4}}, races)
+
+		assert.Equal(t,
+			strings.Join([]string{
+				"1 20 3 [4, 10, 6]",
+				"2 16 1 [10, 6, 0]",
+				"3 16 2 [6, NotFinished, 10]",
+				"4 4 4 [NotStarted, -, 4]",
+			}, "\n")+"\n",
+			standings.String())
+	})
+
+	t.Run("with dropped results", func(t *testing.T) {
+		standings := Calculate(config.Cup{Points: []uint32{10, 6, 4}, DropWorst: 1}, races)
+
+		assert.Equal(t,
+			strings.Join([]string{
+				"1 16 3 [(4), 10, 6]",
+				"2 16 1 [10, 6, (0)]",
+				"3 16 2 [6, (NotFinished), 10]",
+				"4 4 4 [(NotStarted), -, 4]",
+			}, "\n")+"\n",
+			standings.String())
+	})
+
+	t.Run("with equal results", func(t *testing.T) {
+		standings := Calculate(config.Cup{Points: []uint32{10}}, []Race{
+			{Results: []report.JSONResult{finished("1", 1), finished("2", 1)}},
+		})
+
+		assert.Equal(t, "1 10 1 [10]\n1 10 2 [10]\n", standings.String())
+	})
+
+	t.Run("with last race tie-break", func(t *testing.T) {
+		standings := Calculate(config.Cup{
+			Points:    []uint32{10, 6, 4},
+			DropWorst: 1,
+			TieBreak:  config.TieBreakLastRace,
+		}, races)
+
+		assert.Equal(t,
+			strings.Join([]string{
+				"1 16 2 [6, (NotFinished), 10]",
+				"2 16 3 [(4), 10, 6]",
+				"3 16 1 [10, 6, (0)]",
+				"4 4 4 [(NotStarted), -, 4]",
+			}, "\n")+"\n",
+			standings.String())
+	})
+}
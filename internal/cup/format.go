@@ -0,0 +1,90 @@
+package cup
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/report"
+)
+
+// String formats Standings and returns it as string.
+// Each line is related to unique competitor and contains place, points,
+// competitorID and results of each race. Dropped results are in parentheses.
+// Examples of formatted lines (if there were 3 races):
+//
+//	1 165 1 [90, 75, (NotFinished)]
+//	2 140 2 [(-), 75, 65]
+func (standings Standings) String() string {
+	builder := strings.Builder{}
+
+	for _, s := range standings {
+		results := make([]string, 0, len(s.results))
+		for _, result := range s.results {
+			results = append(results, result.String())
+		}
+
+		_, _ = fmt.Fprintf(&builder, "%d %d %s [%s]\n", s.place, s.points, s.competitorID, strings.Join(results, ", "))
+	}
+
+	return builder.String()
+}
+
+// JSONStanding is machine-readable representation of single competitor cup standing.
+type JSONStanding struct {
+	Place        int                `json:"place"`
+	CompetitorID string             `json:"competitorId"`
+	Points       uint32             `json:"points"`
+	Races        []JSONStandingRace `json:"races"`
+}
+
+// JSONStandingRace is machine-readable representation of competitor result in single race.
+type JSONStandingRace struct {
+	// Place in the race, zero if competitor is not ranked.
+	Place int `json:"place,omitempty"`
+	// Status of the competitor, empty if competitor did not take part in the race.
+	Status  report.Status `json:"status,omitempty"`
+	Points  uint32        `json:"points"`
+	Dropped bool          `json:"dropped,omitempty"`
+}
+
+// Write Standings in given format to w.
+func (standings Standings) Write(w io.Writer, format report.Format) error {
+	switch format {
+	case report.FormatText:
+		_, err := fmt.Fprint(w, standings)
+		return err
+	case report.FormatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+
+		return encoder.Encode(standings.jsonStandings())
+	default:
+		return fmt.Errorf("unsupported format: %s", format)
+	}
+}
+
+func (standings Standings) jsonStandings() []JSONStanding {
+	res := make([]JSONStanding, 0, len(standings))
+	for _, s := range standings {
+		races := make([]JSONStandingRace, 0, len(s.results))
+		for _, result := range s.results {
+			races = append(races, JSONStandingRace{
+				Place:   result.place,
+				Status:  result.status,
+				Points:  result.points,
+				Dropped: result.dropped,
+			})
+		}
+
+		res = append(res, JSONStanding{
+			Place:        s.place,
+			CompetitorID: s.competitorID,
+			Points:       s.points,
+			Races:        races,
+		})
+	}
+
+	return res
+}
@@ -0,0 +1,167 @@
+// engine contains concurrency-safe competition engine, that handles events from several producers.
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/competition"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/config"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event/parser"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/report"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/snapshot"
+)
+
+// ErrClosed is returned if the engine is used after Close.
+var ErrClosed = errors.New("engine is closed")
+
+// Engine owns competition.Biathlon, report.Reporter and parser.TargetsChecker and accesses them
+// only from single goroutine (event loop). Methods of Engine can be called from several goroutines:
+// they send commands to the event loop and wait for the result.
+//
+// Events are handled in the order they are received by the event loop, so events of single
+// competitor must be sent by single producer or in chronological order.
+type Engine struct {
+	conf           config.BiathlonCompetition
+	observer       *competition.ComposedObserver
+	biathlon       *competition.Biathlon
+	reporter       *report.Reporter
+	targetsChecker *parser.TargetsChecker
+	handled        int
+
+	// mu protects closed and sending to commands after Close.
+	mu       sync.RWMutex
+	closed   bool
+	commands chan func()
+	stopped  chan struct{}
+}
+
+// New creates Engine with given config and starts its event loop. Observer is notified
+// with all incoming and outgoing events from the event loop goroutine, it may be nil.
+func New(conf config.BiathlonCompetition, observer competition.Observer) (*Engine, error) {
+	reporter := report.NewReporter(conf)
+	composed := competition.NewComposedObserver().AddObservers(reporter, observer)
+
+	biathlon, err := competition.NewBiathlon(conf, composed)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create biathlon competition: %w", err)
+	}
+
+	e := &Engine{
+		conf:           conf,
+		observer:       composed,
+		biathlon:       biathlon,
+		reporter:       reporter,
+		targetsChecker: parser.NewTargetsChecker(conf),
+		commands:       make(chan func()),
+		stopped:        make(chan struct{}),
+	}
+
+	go e.loop()
+
+	return e, nil
+}
+
+func (e *Engine) loop() {
+	defer close(e.stopped)
+
+	for command := range e.commands {
+		command()
+	}
+}
+
+// do runs f in the event loop and waits until it is done.
+func (e *Engine) do(f func()) error {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	if e.closed {
+		return ErrClosed
+	}
+
+	done := make(chan struct{})
+	e.commands <- func() {
+		defer close(done)
+		f()
+	}
+
+	<-done
+
+	return nil
+}
+
+// HandleEvent checks and handles given incoming event. Returns error if the event is not valid
+// (see parser.TargetsChecker), the engine is closed or observers failed on this event
+// (see competition.Biathlon.Err).
+func (e *Engine) HandleEvent(incomingEvent event.Event) error {
+	var err error
+
+	doErr := e.do(func() {
+		err = e.targetsChecker.Check(incomingEvent)
+		if err != nil {
+			return
+		}
+
+		e.biathlon.HandleEvent(incomingEvent)
+		e.handled += 1
+
+		err = errors.Join(e.observer.Err(), e.biathlon.Err())
+	})
+
+	return errors.Join(doErr, err)
+}
+
+// Report returns report of handled events, see report.Reporter.MakeReport.
+func (e *Engine) Report() (report.Report, error) {
+	var res report.Report
+
+	err := e.do(func() {
+		res = e.reporter.MakeReport()
+	})
+
+	return res, err
+}
+
+// ProvisionalReport returns report of the current moment of the race,
+// see report.Reporter.MakeProvisionalReport.
+func (e *Engine) ProvisionalReport() (report.Report, error) {
+	var res report.Report
+
+	err := e.do(func() {
+		res = e.reporter.MakeProvisionalReport()
+	})
+
+	return res, err
+}
+
+// Snapshot returns state of the engine. Offset of snapshot is the amount of handled events.
+func (e *Engine) Snapshot() (snapshot.Snapshot, error) {
+	var res snapshot.Snapshot
+
+	err := e.do(func() {
+		res = snapshot.Snapshot{
+			Offset:      e.handled,
+			Config:      e.conf,
+			Competition: e.biathlon.Snapshot(),
+			Report:      e.reporter.Snapshot(),
+			Targets:     e.targetsChecker.Snapshot(),
+		}
+	})
+
+	return res, err
+}
+
+// Close stops the event loop after all commands sent before are done.
+// After Close all methods return ErrClosed.
+func (e *Engine) Close() {
+	e.mu.Lock()
+	if !e.closed {
+		e.closed = true
+		close(e.commands)
+	}
+	e.mu.Unlock()
+
+	<-e.stopped
+}
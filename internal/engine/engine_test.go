@@ -0,0 +1,169 @@
+package engine
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/competition"
+	mock_observer "github.com/AleksandrMatsko/yadro-biathlon/internal/competition/mocks"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/config"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/report"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/simulator"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+)
+
+var testConfig = config.BiathlonCompetition{
+	Laps:        2,
+	LapLen:      3000,
+	PenaltyLen:  150,
+	FiringLines: 1,
+	Start:       "10:00:00.000",
+	StartDelta:  "00:00:30",
+}
+
+// competitorEvents returns simulated events of given amount of competitors grouped by competitor.
+func competitorEvents(t *testing.T, competitors int) [][]event.Event {
+	t.Helper()
+
+	params := simulator.DefaultParams()
+	params.Competitors = competitors
+
+	events, err := simulator.GenerateByCompetitor(testConfig, params)
+	assert.Nil(t, err)
+
+	return events
+}
+
+func Test_Engine_concurrentProducers(t *testing.T) {
+	events := competitorEvents(t, 50)
+
+	expectedReporter := report.NewReporter(testConfig)
+	biathlon, err := competition.NewBiathlon(testConfig, expectedReporter)
+	assert.Nil(t, err)
+
+	handled := 0
+
+	for _, competitor := range events {
+		for _, e := range competitor {
+			biathlon.HandleEvent(e)
+		}
+
+		handled += len(competitor)
+	}
+
+	engine, err := New(testConfig, nil)
+	assert.Nil(t, err)
+
+	wg := sync.WaitGroup{}
+
+	for _, competitor := range events {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for _, e := range competitor {
+				assert.Nil(t, engine.HandleEvent(e))
+			}
+		}()
+	}
+
+	for range 5 {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for range 20 {
+				_, err := engine.ProvisionalReport()
+				assert.Nil(t, err)
+
+				_, err = engine.Snapshot()
+				assert.Nil(t, err)
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	actual, err := engine.Report()
+	assert.Nil(t, err)
+	assert.Equal(t, expectedReporter.MakeReport(), actual)
+
+	saved, err := engine.Snapshot()
+	assert.Nil(t, err)
+	assert.Equal(t, handled, saved.Offset)
+
+	engine.Close()
+}
+
+func Test_Engine_Close(t *testing.T) {
+	engine, err := New(testConfig, nil)
+	assert.Nil(t, err)
+
+	events := competitorEvents(t, 10)
+	wg := sync.WaitGroup{}
+
+	for _, competitor := range events {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for _, e := range competitor {
+				err := engine.HandleEvent(e)
+				if err != nil {
+					assert.ErrorIs(t, err, ErrClosed)
+					return
+				}
+			}
+		}()
+	}
+
+	engine.Close()
+	engine.Close()
+	wg.Wait()
+
+	assert.ErrorIs(t, engine.HandleEvent(events[0][0]), ErrClosed)
+
+	_, err = engine.Report()
+	assert.ErrorIs(t, err, ErrClosed)
+}
+
+func Test_Engine_HandleEvent_withInvalidEvent(t *testing.T) {
+	engine, err := New(testConfig, nil)
+	assert.Nil(t, err)
+	defer engine.Close()
+
+	events := competitorEvents(t, 1)[0]
+	for _, e := range events {
+		assert.Nil(t, engine.HandleEvent(e))
+	}
+
+	assert.NotNil(t, engine.HandleEvent(event.Event{Time: events[len(events)-1].Time, ID: event.TargetHit, CompetitorID: "1", Extra: "6"}))
+}
+
+func Test_Engine_HandleEvent_withPanickingObserver(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	observer := mock_observer.NewMockObserver(mockCtrl)
+
+	engine, err := New(testConfig, observer)
+	assert.Nil(t, err)
+	defer engine.Close()
+
+	events := competitorEvents(t, 1)[0]
+
+	observer.EXPECT().NotifyWithEvent(events[0])
+	observer.EXPECT().NotifyWithEvent(events[1]).Do(func(event.Event) { panic("bad observer") })
+
+	assert.Nil(t, engine.HandleEvent(events[0]))
+	assert.ErrorIs(t, engine.HandleEvent(events[1]), competition.ErrObserverPanic)
+
+	t.Run("next event is handled without error", func(t *testing.T) {
+		observer.EXPECT().NotifyWithEvent(gomock.Any()).AnyTimes()
+
+		assert.Nil(t, engine.HandleEvent(events[2]))
+	})
+}
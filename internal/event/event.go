@@ -3,6 +3,7 @@ package event
 
 import (
 	"fmt"
+	"strings"
 	"time"
 )
 
@@ -22,10 +23,62 @@ const (
 	CompetitorLeftPenaltyLaps  EventID = 9
 	CompetitorEndedMainLap     EventID = 10
 	CompetitorCannotContinue   EventID = 11
+	ShotFired                  EventID = 12
+	JuryTimePenalty            EventID = 13
+	JuryTimeBonus              EventID = 14
+	JuryReinstatement          EventID = 15
+	JuryDisqualification       EventID = 16
 	CompetitorDisqualified     EventID = 32
 	CompetitorFinished         EventID = 33
+	CompetitorLapped           EventID = 34
 )
 
+// Results of the shot in extra of ShotFired event.
+const (
+	ShotHit  = "hit"
+	ShotMiss = "miss"
+)
+
+// DisqualificationReason is machine-readable reason of disqualification.
+type DisqualificationReason string
+
+// constants define possible reasons of disqualification.
+const (
+	// ReasonLateStart - the competitor has not started during his/her start interval.
+	ReasonLateStart DisqualificationReason = "lateStart"
+	// ReasonMissedPenalty - the competitor has not run penalty laps after shooting with misses.
+	ReasonMissedPenalty DisqualificationReason = "missedPenalty"
+	// ReasonJuryDecision - the competitor has been disqualified by the jury.
+	ReasonJuryDecision DisqualificationReason = "juryDecision"
+)
+
+// Disqualification is extra of CompetitorDisqualified event.
+type Disqualification struct {
+	Reason DisqualificationReason
+	// Text is human-readable description of the reason.
+	Text string
+}
+
+// String formats Disqualification as extra of CompetitorDisqualified event: "reason text".
+func (d Disqualification) String() string {
+	if d.Text == "" {
+		return string(d.Reason)
+	}
+
+	return string(d.Reason) + " " + d.Text
+}
+
+// ParseDisqualification from extra of CompetitorDisqualified event.
+// Extra may be empty if the reason is unknown.
+func ParseDisqualification(extra string) Disqualification {
+	reason, text, _ := strings.Cut(extra, " ")
+
+	return Disqualification{
+		Reason: DisqualificationReason(reason),
+		Text:   text,
+	}
+}
+
 // Event respesents incoming or outgoing event happened with competitor.
 type Event struct {
 	// Time of event happened.
@@ -40,7 +93,12 @@ type Event struct {
 
 // ValidIncomingEventID checks if the given value is a valid incoming event id.
 func ValidIncomingEventID(candidate uint8) bool {
-	return candidate >= uint8(CompetitorRegistration) && candidate <= uint8(CompetitorCannotContinue)
+	return candidate >= uint8(CompetitorRegistration) && candidate <= uint8(JuryDisqualification)
+}
+
+// IsJuryCorrection checks if the given event id is one of jury corrections.
+func IsJuryCorrection(id EventID) bool {
+	return id >= JuryTimePenalty && id <= JuryDisqualification
 }
 
 // TimeFormat use to parse.
@@ -48,51 +106,85 @@ const TimeFormat = "15:04:05.000"
 
 // formatTime according to TimeFormat.
 func (e Event) formatTime() string {
-	return fmt.Sprintf("[%s]", e.Time.Format(TimeFormat))
+	// buffer with spare capacity, so formatting does not grow it.
+	var buf [32]byte
+
+	return string(e.appendTime(buf[:0]))
+}
+
+// appendTime appends time formatted as in formatTime to b.
+func (e Event) appendTime(b []byte) []byte {
+	b = append(b, '[')
+	b = e.Time.AppendFormat(b, TimeFormat)
+
+	return append(b, ']')
+}
+
+// Line formats event in the same way as it is written in events file:
+//
+//	[HH:MM:SS.sss] eventID competitorID extra
+func (e Event) Line() string {
+	line := fmt.Sprintf("%s %d %s", e.formatTime(), e.ID, e.CompetitorID)
+	if e.Extra != "" {
+		line += " " + e.Extra
+	}
+
+	return line
 }
 
 // String method to implement Stringer interface.
 func (e Event) String() string {
-	formattedTime := e.formatTime() + " "
-
 	eventMsg := "unknown event"
 	switch e.ID {
 	case CompetitorRegistration:
-		eventMsg = fmt.Sprintf("The competitor(%s) registered", e.CompetitorID)
+		eventMsg = "The competitor(" + e.CompetitorID + ") registered"
 	case StartTimeAssignment:
-		eventMsg = fmt.Sprintf("The start time for the competitor(%s) was set by a draw to %s", e.CompetitorID, e.Extra)
+		eventMsg = "The start time for the competitor(" + e.CompetitorID + ") was set by a draw to " + e.Extra
 	case CompetitorOnStartine:
-		eventMsg = fmt.Sprintf("The competitor(%s) is on the start line", e.CompetitorID)
+		eventMsg = "The competitor(" + e.CompetitorID + ") is on the start line"
 	case CompetitorStarted:
-		eventMsg = fmt.Sprintf("The competitor(%s) has started", e.CompetitorID)
+		eventMsg = "The competitor(" + e.CompetitorID + ") has started"
 	case CompetitorOnFiringRange:
-		eventMsg = fmt.Sprintf("The competitor(%s) is on the firing range(%s)", e.CompetitorID, e.Extra)
+		eventMsg = "The competitor(" + e.CompetitorID + ") is on the firing range(" + e.Extra + ")"
 	case TargetHit:
-		eventMsg = fmt.Sprintf("The target(%s) has been hit by competitor(%s)", e.Extra, e.CompetitorID)
+		eventMsg = "The target(" + e.Extra + ") has been hit by competitor(" + e.CompetitorID + ")"
 	case CompetitorLeftFiringRange:
-		eventMsg = fmt.Sprintf("The competitor(%s) left the firing range", e.CompetitorID)
+		eventMsg = "The competitor(" + e.CompetitorID + ") left the firing range"
 	case CompetitorEnterPenaltyLaps:
-		eventMsg = fmt.Sprintf("The competitor(%s) entered the penalty laps", e.CompetitorID)
+		eventMsg = "The competitor(" + e.CompetitorID + ") entered the penalty laps"
 	case CompetitorLeftPenaltyLaps:
-		eventMsg = fmt.Sprintf("The competitor(%s) left the penalty laps", e.CompetitorID)
+		eventMsg = "The competitor(" + e.CompetitorID + ") left the penalty laps"
 	case CompetitorEndedMainLap:
-		eventMsg = fmt.Sprintf("The competitor(%s) ended the main lap", e.CompetitorID)
+		eventMsg = "The competitor(" + e.CompetitorID + ") ended the main lap"
 	case CompetitorCannotContinue:
-		eventMsg = fmt.Sprintf("The competitor(%s) can`t continue: %s", e.CompetitorID, e.Extra)
+		eventMsg = "The competitor(" + e.CompetitorID + ") can`t continue: " + e.Extra
+	case ShotFired:
+		shotNumber, shotResult, _ := strings.Cut(e.Extra, " ")
+		eventMsg = "The shot(" + shotNumber + ") fired by competitor(" + e.CompetitorID + ") is a " + shotResult
+	case JuryTimePenalty:
+		eventMsg = "The jury added time penalty to competitor(" + e.CompetitorID + "): " + e.Extra
+	case JuryTimeBonus:
+		eventMsg = "The jury gave time bonus to competitor(" + e.CompetitorID + "): " + e.Extra
+	case JuryReinstatement:
+		eventMsg = "The competitor(" + e.CompetitorID + ") has been reinstated by the jury"
+		if e.Extra != "" {
+			eventMsg += ": " + e.Extra
+		}
+	case JuryDisqualification:
+		eventMsg = "The jury disqualified the competitor(" + e.CompetitorID + "): " + e.Extra
 	case CompetitorDisqualified:
-		eventMsg = fmt.Sprintf("The competitor(%s) has been disqualified", e.CompetitorID)
+		eventMsg = "The competitor(" + e.CompetitorID + ") has been disqualified"
+		if disqualification := ParseDisqualification(e.Extra); disqualification.Text != "" {
+			eventMsg += ": " + disqualification.Text
+		}
 	case CompetitorFinished:
-		eventMsg = fmt.Sprintf("The competitor(%s) has finished", e.CompetitorID)
+		eventMsg = "The competitor(" + e.CompetitorID + ") has finished"
+	case CompetitorLapped:
+		eventMsg = "The competitor(" + e.CompetitorID + ") has been lapped by the leader"
 	}
 
-	return formattedTime + eventMsg
-}
+	var buf [32]byte
 
-// AvailableTargets is a set of available targets, that can be hit.
-var AvailableTargets = map[string]struct{}{
-	"1": {},
-	"2": {},
-	"3": {},
-	"4": {},
-	"5": {},
+	// converted bytes are not copied before concatenation.
+	return string(e.appendTime(buf[:0])) + " " + eventMsg
 }
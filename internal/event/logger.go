@@ -1,7 +1,6 @@
 package event
 
 import (
-	"fmt"
 	"io"
 	"os"
 )
@@ -24,5 +23,12 @@ func NewLogger(w io.Writer) *Logger {
 
 // NotifyWithEvent logs received event.
 func (l *Logger) NotifyWithEvent(e Event) {
-	fmt.Fprintln(l.writer, e)
+	_ = l.TryNotifyWithEvent(e)
+}
+
+// TryNotifyWithEvent logs received event and returns error of writing.
+func (l *Logger) TryNotifyWithEvent(e Event) error {
+	_, err := io.WriteString(l.writer, e.String()+"\n")
+
+	return err
 }
@@ -0,0 +1,94 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// ErrBadFiringLine is returned by FiringLines.Visit if the competitor can not visit the firing line.
+var ErrBadFiringLine = errors.New("bad firing line")
+
+// FiringLines tracks visits of firing lines by single competitor and decides, which firing line
+// is visited. It is shared by TargetsChecker, referees and reporter, so they count the same shootings.
+//
+// If firing lines are placed at the end of specific laps, firing line must be visited at the end
+// of its lap and not visited yet, skipped firing lines are counted as visited. Otherwise firing line
+// is taken from the event, if it is a number of firing line, or firing lines are visited in order.
+// In both cases competitor can not visit more firing lines, than there are.
+type FiringLines struct {
+	firingLines    uint32
+	firingLineLaps []uint32
+	// visited - amount of visited or skipped firing lines.
+	visited       uint32
+	lapsCompleted uint32
+}
+
+// FiringLinesSnapshot is serializable state of FiringLines.
+type FiringLinesSnapshot struct {
+	Visited       uint32 `json:"visited"`
+	LapsCompleted uint32 `json:"lapsCompleted"`
+}
+
+// NewFiringLines creates FiringLines for competition with given amount of firing lines.
+// firingLineLaps is optional number of lap, which ends with firing line, for each firing line.
+func NewFiringLines(firingLines uint32, firingLineLaps []uint32) *FiringLines {
+	return &FiringLines{
+		firingLines:    firingLines,
+		firingLineLaps: firingLineLaps,
+	}
+}
+
+// Visit counts visit of firing line from CompetitorOnFiringRange event and returns index of the visited
+// firing line. Returns ErrBadFiringLine if the visit is not allowed, such visit is not counted.
+func (f *FiringLines) Visit(firingLine string) (uint32, error) {
+	if f.visited >= f.firingLines {
+		return 0, fmt.Errorf("%w: %s, all %d firing lines are visited", ErrBadFiringLine, firingLine, f.firingLines)
+	}
+
+	number, err := strconv.ParseUint(firingLine, 10, 32)
+	known := err == nil && number != 0 && number <= uint64(f.firingLines)
+
+	if len(f.firingLineLaps) == 0 {
+		index := f.visited
+		if known {
+			index = uint32(number) - 1
+		}
+
+		f.visited += 1
+
+		return index, nil
+	}
+
+	if !known || uint32(number) <= f.visited {
+		return 0, fmt.Errorf("%w: %s, visited firing lines: %d", ErrBadFiringLine, firingLine, f.visited)
+	}
+
+	if f.firingLineLaps[number-1] != f.lapsCompleted+1 {
+		return 0, fmt.Errorf("%w: %s is placed at the end of lap %d, not %d",
+			ErrBadFiringLine, firingLine, f.firingLineLaps[number-1], f.lapsCompleted+1)
+	}
+
+	f.visited = uint32(number)
+
+	return f.visited - 1, nil
+}
+
+// EndLap counts completed main lap.
+func (f *FiringLines) EndLap() {
+	f.lapsCompleted += 1
+}
+
+// Snapshot returns current state of f.
+func (f *FiringLines) Snapshot() FiringLinesSnapshot {
+	return FiringLinesSnapshot{
+		Visited:       f.visited,
+		LapsCompleted: f.lapsCompleted,
+	}
+}
+
+// Restore replaces state of f with the given snapshot.
+func (f *FiringLines) Restore(snapshot FiringLinesSnapshot) {
+	f.visited = snapshot.Visited
+	f.lapsCompleted = snapshot.LapsCompleted
+}
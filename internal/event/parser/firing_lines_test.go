@@ -0,0 +1,62 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_FiringLines(t *testing.T) {
+	t.Run("without firing line laps", func(t *testing.T) {
+		firingLines := NewFiringLines(2, nil)
+
+		index, err := firingLines.Visit("2")
+		assert.Nil(t, err)
+		assert.Equal(t, uint32(1), index)
+
+		index, err = firingLines.Visit("unknown")
+		assert.Nil(t, err)
+		assert.Equal(t, uint32(1), index)
+
+		_, err = firingLines.Visit("1")
+		assert.ErrorIs(t, err, ErrBadFiringLine)
+	})
+
+	t.Run("with firing line laps", func(t *testing.T) {
+		firingLines := NewFiringLines(3, []uint32{1, 2, 4})
+
+		index, err := firingLines.Visit("1")
+		assert.Nil(t, err)
+		assert.Equal(t, uint32(0), index)
+
+		firingLines.EndLap()
+
+		for _, firingLine := range []string{"1", "3", "4", "unknown"} {
+			_, err = firingLines.Visit(firingLine)
+			assert.ErrorIs(t, err, ErrBadFiringLine, firingLine)
+		}
+
+		firingLines.EndLap()
+		firingLines.EndLap()
+
+		index, err = firingLines.Visit("3")
+		assert.Nil(t, err)
+		assert.Equal(t, uint32(2), index)
+
+		_, err = firingLines.Visit("3")
+		assert.ErrorIs(t, err, ErrBadFiringLine)
+	})
+
+	t.Run("snapshot and restore", func(t *testing.T) {
+		firingLines := NewFiringLines(2, []uint32{1, 2})
+
+		_, err := firingLines.Visit("1")
+		assert.Nil(t, err)
+		firingLines.EndLap()
+
+		restored := NewFiringLines(2, []uint32{1, 2})
+		restored.Restore(firingLines.Snapshot())
+
+		assert.Equal(t, firingLines, restored)
+	})
+}
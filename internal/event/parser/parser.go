@@ -43,39 +43,52 @@ func ParsedLines(lines iter.Seq[string]) iter.Seq2[event.Event, error] {
 	}
 }
 
-const maxNumParts = 4
+const minNumParts = 3
 
 // ParseSingleLine expect line to have such format:
 //
 //	[HH:MM:SS.sss] eventID competitorID extra
 //
 // If there are less than 3 arguments or if values are invalid,
-// the error is returned.
+// the error is returned. Line is split without allocations, so CompetitorID
+// and Extra of the event refer to the line.
 func ParseSingleLine(line string) (event.Event, error) {
-	split := strings.SplitN(line, " ", maxNumParts)
-
-	if len(split) < maxNumParts-1 {
-		argsCount := len(split)
-		if line == "" {
-			argsCount = 0
-		}
+	timeField, rest, found := strings.Cut(line, " ")
+	if !found {
+		return event.Event{}, errNotEnoughArguments(line, 1)
+	}
 
-		return event.Event{}, fmt.Errorf("not enough arguments, expected at least 3, got %d", argsCount)
+	eventIDField, rest, found := strings.Cut(rest, " ")
+	if !found {
+		return event.Event{}, errNotEnoughArguments(line, 2)
 	}
 
-	parsedTime, err := ParseTime(strings.Trim(split[0], "[]"))
+	competitorID, extra, _ := strings.Cut(rest, " ")
+
+	parsedTime, err := ParseTime(strings.Trim(timeField, "[]"))
 	if err != nil {
 		return event.Event{}, fmt.Errorf("failed to parse event timestamp: %w", err)
 	}
 
-	eventID, err := ParseEventID(split[1])
+	eventID, err := ParseEventID(eventIDField)
 	if err != nil {
 		return event.Event{}, fmt.Errorf("failed to parse event id: %w", err)
 	}
 
-	extra := ""
-	if len(split) == maxNumParts {
-		extra = split[3]
+	if eventID == event.ShotFired {
+		if _, err := ParseShot(extra); err != nil {
+			return event.Event{}, fmt.Errorf("bad shot: %w", err)
+		}
+	}
+
+	if eventID == event.JuryTimePenalty || eventID == event.JuryTimeBonus {
+		if _, err := ParseTimeCorrection(extra); err != nil {
+			return event.Event{}, fmt.Errorf("bad time correction: %w", err)
+		}
+	}
+
+	if eventID == event.JuryDisqualification && extra == "" {
+		return event.Event{}, fmt.Errorf("reason of disqualification is required")
 	}
 
 	if extra != "" {
@@ -87,7 +100,7 @@ func ParseSingleLine(line string) (event.Event, error) {
 		}
 
 		if eventID == event.TargetHit {
-			if _, ok := event.AvailableTargets[extra]; !ok {
+			if _, err := ParseTarget(extra); err != nil {
 				return event.Event{}, fmt.Errorf("bad target: %s", extra)
 			}
 		}
@@ -96,19 +109,86 @@ func ParseSingleLine(line string) (event.Event, error) {
 	return event.Event{
 		Time:         parsedTime,
 		ID:           eventID,
-		CompetitorID: split[2],
+		CompetitorID: competitorID,
 		Extra:        extra,
 	}, nil
 }
 
+func errNotEnoughArguments(line string, argsCount int) error {
+	if line == "" {
+		argsCount = 0
+	}
+
+	return fmt.Errorf("not enough arguments, expected at least %d, got %d", minNumParts, argsCount)
+}
+
 // ParseTime from given string into time.Time according to event.TimeFormat.
+// Time of each event is parsed, so time exactly in event.TimeFormat is parsed without time.Parse,
+// other strings (e.g. with single digit hour or invalid) are passed to time.Parse.
 func ParseTime(s string) (time.Time, error) {
+	if parsed, ok := parseExactTime(s); ok {
+		return parsed, nil
+	}
+
 	return time.Parse(event.TimeFormat, s)
 }
 
+const hoursPerDay = 24
+
+// parseExactTime parses valid time, that matches event.TimeFormat char by char.
+func parseExactTime(s string) (time.Time, bool) {
+	if len(s) != len(event.TimeFormat) {
+		return time.Time{}, false
+	}
+
+	// numbers are HH, MM, SS and sss in order of event.TimeFormat.
+	var numbers [4]int
+
+	number := 0
+
+	for i := range len(s) {
+		if !isDigit(event.TimeFormat[i]) {
+			if s[i] != event.TimeFormat[i] {
+				return time.Time{}, false
+			}
+
+			number += 1
+
+			continue
+		}
+
+		if !isDigit(s[i]) {
+			return time.Time{}, false
+		}
+
+		numbers[number] = numbers[number]*10 + int(s[i]-'0')
+	}
+
+	hours, minutes, seconds, milliseconds := numbers[0], numbers[1], numbers[2], numbers[3]
+	if hours >= hoursPerDay || minutes >= 60 || seconds >= 60 {
+		return time.Time{}, false
+	}
+
+	return time.Date(0, time.January, 1, hours, minutes, seconds, milliseconds*int(time.Millisecond), time.UTC), true
+}
+
+func isDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
+
+// ParseDuration from given string in format HH:MM:SS[.sss] into time.Duration.
+func ParseDuration(s string) (time.Duration, error) {
+	parsedTime, err := time.Parse(time.TimeOnly, s)
+	if err != nil {
+		return 0, err
+	}
+
+	return parsedTime.Sub(time.Date(0, time.January, 1, 0, 0, 0, 0, parsedTime.Location())), nil
+}
+
 // ParseEventID from given string into event ID.
 func ParseEventID(s string) (event.EventID, error) {
-	val, err := strconv.ParseUint(s, 10, 64)
+	val, err := strconv.ParseUint(s, 10, 8)
 	if err != nil {
 		return 0, fmt.Errorf("failed to parse value: %w", err)
 	}
@@ -120,3 +200,67 @@ func ParseEventID(s string) (event.EventID, error) {
 
 	return event.EventID(converted), nil
 }
+
+// ParseTarget from given string into target number. Targets are numbered from 1.
+func ParseTarget(s string) (uint32, error) {
+	val, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse value: %w", err)
+	}
+
+	if val == 0 {
+		return 0, fmt.Errorf("invalid target: %s", s)
+	}
+
+	return uint32(val), nil
+}
+
+// Shot is parsed extra of event.ShotFired.
+type Shot struct {
+	// Number of the shot on the firing line, starting from 1.
+	Number uint32
+	// Hit is true if the shot hit the target.
+	Hit bool
+}
+
+// ParseShot from given string in format "shotNumber hit|miss".
+func ParseShot(s string) (Shot, error) {
+	number, result, _ := strings.Cut(s, " ")
+
+	shotNumber, err := strconv.ParseUint(number, 10, 32)
+	if err != nil || shotNumber == 0 {
+		return Shot{}, fmt.Errorf("invalid shot number: '%s'", number)
+	}
+
+	if result != event.ShotHit && result != event.ShotMiss {
+		return Shot{}, fmt.Errorf("invalid shot result: '%s', expected %s or %s", result, event.ShotHit, event.ShotMiss)
+	}
+
+	return Shot{
+		Number: uint32(shotNumber),
+		Hit:    result == event.ShotHit,
+	}, nil
+}
+
+// TimeCorrection is parsed extra of event.JuryTimePenalty and event.JuryTimeBonus.
+type TimeCorrection struct {
+	// Duration of penalty or bonus.
+	Duration time.Duration
+	// Reason of the correction, may be empty.
+	Reason string
+}
+
+// ParseTimeCorrection from given string in format "HH:MM:SS[.sss] [reason]".
+func ParseTimeCorrection(s string) (TimeCorrection, error) {
+	duration, reason, _ := strings.Cut(s, " ")
+
+	parsedDuration, err := ParseDuration(duration)
+	if err != nil || parsedDuration == 0 {
+		return TimeCorrection{}, fmt.Errorf("invalid duration: '%s', expected HH:MM:SS[.sss] greater than zero", duration)
+	}
+
+	return TimeCorrection{
+		Duration: parsedDuration,
+		Reason:   reason,
+	}, nil
+}
@@ -1,12 +1,16 @@
 package parser
 
 import (
+	"bytes"
 	"fmt"
 	"iter"
+	"os"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/benchmark"
 	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
 	"github.com/stretchr/testify/assert"
 )
@@ -188,6 +192,13 @@ func Test_ParseSingleLine(t *testing.T) {
 			assert.Equal(t, event.Event{}, gotEvent)
 			assert.Equal(t, err, fmt.Errorf("failed to parse event id: %w", fmt.Errorf("invalid event id: 0")))
 		})
+
+		t.Run("with value overflowing event id", func(t *testing.T) {
+			gotEvent, err := ParseSingleLine("[09:05:59.867] 257 1")
+
+			assert.Equal(t, event.Event{}, gotEvent)
+			assert.NotNil(t, err)
+		})
 	})
 
 	t.Run("with bad extra", func(t *testing.T) {
@@ -206,5 +217,165 @@ func Test_ParseSingleLine(t *testing.T) {
 			assert.Equal(t, event.Event{}, gotEvent)
 			assert.Equal(t, fmt.Errorf("bad target: hello"), err)
 		})
+
+		t.Run("with bad time correction", func(t *testing.T) {
+			gotEvent, err := ParseSingleLine("[09:05:59.867] 13 1 two minutes")
+
+			assert.Equal(t, event.Event{}, gotEvent)
+			assert.NotNil(t, err)
+		})
+
+		t.Run("without reason of disqualification", func(t *testing.T) {
+			gotEvent, err := ParseSingleLine("[09:05:59.867] 16 1")
+
+			assert.Equal(t, event.Event{}, gotEvent)
+			assert.NotNil(t, err)
+		})
+
+		t.Run("with bad shot", func(t *testing.T) {
+			gotEvent, err := ParseSingleLine("[09:05:59.867] 12 1 3")
+
+			assert.Equal(t, event.Event{}, gotEvent)
+			assert.NotNil(t, err)
+		})
+	})
+}
+
+func Test_ParseTime(t *testing.T) {
+	cases := []string{
+		"00:00:00.000",
+		"09:05:59.867",
+		"23:59:59.999",
+		"9:05:59.867",
+		"24:00:00.000",
+		"12:60:00.000",
+		"12:00:60.000",
+		"12:00:00",
+		"12:00:00.1234",
+		"12-00-00.000",
+		"ab:cd:ef.ghi",
+		"",
+	}
+
+	for _, s := range cases {
+		t.Run(s, func(t *testing.T) {
+			expected, expectedErr := time.Parse(event.TimeFormat, s)
+			actual, err := ParseTime(s)
+
+			assert.Equal(t, expected, actual)
+			assert.Equal(t, expectedErr, err)
+		})
+	}
+}
+
+func Test_ParseTimeCorrection(t *testing.T) {
+	correction, err := ParseTimeCorrection("00:02:00 missed penalty lap")
+	assert.Nil(t, err)
+	assert.Equal(t, TimeCorrection{Duration: 2 * time.Minute, Reason: "missed penalty lap"}, correction)
+
+	correction, err = ParseTimeCorrection("00:00:01.500")
+	assert.Nil(t, err)
+	assert.Equal(t, TimeCorrection{Duration: 1500 * time.Millisecond}, correction)
+
+	for _, s := range []string{"", "00:00:00", "2m"} {
+		_, err = ParseTimeCorrection(s)
+		assert.NotNil(t, err, s)
+	}
+}
+
+// benchmarkLines returns lines of test events file with all kinds of events.
+func benchmarkLines(b *testing.B) []string {
+	b.Helper()
+
+	data, err := os.ReadFile("../../../testdata/events")
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	return strings.Split(strings.TrimSpace(string(data)), "\n")
+}
+
+func Benchmark_ParseSingleLine(b *testing.B) {
+	lines := benchmarkLines(b)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := range b.N {
+		_, err := ParseSingleLine(lines[i%len(lines)])
+		if err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	benchmark.ReportThroughput(b, b.N, "lines/s", benchmark.ParseSingleLineTarget)
+}
+
+func Benchmark_ParsedLines(b *testing.B) {
+	const repeats = 1000
+
+	lines := benchmarkLines(b)
+	data := []byte(strings.Repeat(strings.Join(lines, "\n")+"\n", repeats))
+
+	b.ReportAllocs()
+	b.SetBytes(int64(len(data)))
+	b.ResetTimer()
+
+	for range b.N {
+		parsedLines, retErrFunc := Lines(bytes.NewReader(data))
+		for _, err := range ParsedLines(parsedLines) {
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+
+		if err := retErrFunc(); err != nil {
+			b.Fatal(err)
+		}
+	}
+
+	benchmark.ReportThroughput(b, b.N*len(lines)*repeats, "lines/s", benchmark.ParsedLinesTarget)
+}
+
+// eventsFiles are events files from testdata and task, they are used as seed corpus of fuzz tests.
+var eventsFiles = []string{
+	"../../../testdata/events",
+	"../../../testdata/from_task_readme/events",
+	"../../../task/sunny_5_skiers/events",
+}
+
+func Fuzz_ParseSingleLine(f *testing.F) {
+	for _, path := range eventsFiles {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		for _, line := range strings.Split(string(data), "\n") {
+			f.Add(line)
+		}
+	}
+
+	f.Fuzz(func(t *testing.T, line string) {
+		parsed, err := ParseSingleLine(line)
+		if err != nil {
+			assert.Equal(t, event.Event{}, parsed)
+			return
+		}
+
+		assert.True(t, event.ValidIncomingEventID(uint8(parsed.ID)), "invalid event id: %d", parsed.ID)
+
+		_, rest, _ := strings.Cut(line, " ")
+		eventIDField, _, _ := strings.Cut(rest, " ")
+		eventID, err := strconv.ParseUint(eventIDField, 10, 64)
+		assert.Nil(t, err)
+		assert.Equal(t, eventID, uint64(parsed.ID), "event id is changed")
+
+		assert.Equal(t, parsed.Time, parsed.Time.Truncate(time.Millisecond))
+		assert.Less(t, parsed.Time.Sub(time.Date(0, time.January, 1, 0, 0, 0, 0, time.UTC)), 24*time.Hour)
+
+		reparsed, err := ParseSingleLine(parsed.Line())
+		assert.Nil(t, err, "formatted line is not parsed: %s", parsed.Line())
+		assert.Equal(t, parsed, reparsed)
 	})
 }
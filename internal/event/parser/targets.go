@@ -0,0 +1,130 @@
+package parser
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/config"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
+)
+
+// TargetsChecker checks that hit targets exist on the firing line,
+// where the competitor is, and that the competitor visits firing lines in allowed order (see FiringLines).
+type TargetsChecker struct {
+	targetsPerFiringLine []uint32
+	firingLineLaps       []uint32
+	defaultTargets       uint32
+	currentTargets       map[string]uint32
+	firingLines          map[string]*FiringLines
+}
+
+// TargetsCheckerSnapshot is serializable state of TargetsChecker.
+type TargetsCheckerSnapshot struct {
+	// Targets - number of targets on the current firing line of each competitor.
+	Targets map[string]uint32 `json:"targets"`
+	// FiringLines - visits of firing lines of each competitor.
+	FiringLines map[string]FiringLinesSnapshot `json:"firingLines,omitempty"`
+}
+
+// NewTargetsChecker creates TargetsChecker with number of targets from config.
+// Targets of unknown firing line are limited with maximum number of targets on firing line.
+func NewTargetsChecker(conf config.BiathlonCompetition) *TargetsChecker {
+	targetsPerFiringLine := conf.TargetsPerFiringLine()
+
+	defaultTargets := uint32(config.DefaultTargets)
+	if conf.Targets != 0 {
+		defaultTargets = conf.Targets
+	}
+
+	if len(targetsPerFiringLine) != 0 {
+		defaultTargets = slices.Max(targetsPerFiringLine)
+	}
+
+	return &TargetsChecker{
+		targetsPerFiringLine: targetsPerFiringLine,
+		firingLineLaps:       conf.FiringLineLaps,
+		defaultTargets:       defaultTargets,
+		currentTargets:       make(map[string]uint32),
+		firingLines:          make(map[string]*FiringLines),
+	}
+}
+
+// Snapshot returns number of targets on the current firing line and visits of firing lines of each competitor.
+func (c *TargetsChecker) Snapshot() TargetsCheckerSnapshot {
+	snapshot := TargetsCheckerSnapshot{
+		Targets:     maps.Clone(c.currentTargets),
+		FiringLines: make(map[string]FiringLinesSnapshot, len(c.firingLines)),
+	}
+
+	for competitorID, firingLines := range c.firingLines {
+		snapshot.FiringLines[competitorID] = firingLines.Snapshot()
+	}
+
+	return snapshot
+}
+
+// Restore replaces state of the checker with the given one, taken from Snapshot.
+func (c *TargetsChecker) Restore(snapshot TargetsCheckerSnapshot) {
+	clear(c.currentTargets)
+	maps.Copy(c.currentTargets, snapshot.Targets)
+
+	clear(c.firingLines)
+
+	for competitorID, firingLinesSnapshot := range snapshot.FiringLines {
+		c.firingLinesOf(competitorID).Restore(firingLinesSnapshot)
+	}
+}
+
+// Check given event. If the event is CompetitorOnFiringRange, the competitor must be allowed
+// to visit the firing line, see FiringLines. If the event is TargetHit or ShotFired, its target or shot
+// must exist on the firing line from the last CompetitorOnFiringRange event of the same competitor.
+// If the competitor has not visited firing line, target must not be greater than maximum number of targets.
+func (c *TargetsChecker) Check(e event.Event) error {
+	switch e.ID {
+	case event.CompetitorOnFiringRange:
+		index, err := c.firingLinesOf(e.CompetitorID).Visit(e.Extra)
+		if err != nil {
+			return fmt.Errorf("competitor(%s): %w", e.CompetitorID, err)
+		}
+
+		c.currentTargets[e.CompetitorID] = c.targetsPerFiringLine[index]
+	case event.CompetitorEndedMainLap:
+		c.firingLinesOf(e.CompetitorID).EndLap()
+	case event.TargetHit:
+		targets := c.targetsOfCompetitor(e.CompetitorID)
+
+		target, err := ParseTarget(e.Extra)
+		if err != nil || target > targets {
+			return fmt.Errorf("bad target: %s, competitor(%s) is on firing line with %d targets", e.Extra, e.CompetitorID, targets)
+		}
+	case event.ShotFired:
+		targets := c.targetsOfCompetitor(e.CompetitorID)
+
+		shot, err := ParseShot(e.Extra)
+		if err != nil || shot.Number > targets {
+			return fmt.Errorf("bad shot: %s, competitor(%s) is on firing line with %d targets", e.Extra, e.CompetitorID, targets)
+		}
+	}
+
+	return nil
+}
+
+func (c *TargetsChecker) firingLinesOf(competitorID string) *FiringLines {
+	firingLines, ok := c.firingLines[competitorID]
+	if !ok {
+		firingLines = NewFiringLines(uint32(len(c.targetsPerFiringLine)), c.firingLineLaps)
+		c.firingLines[competitorID] = firingLines
+	}
+
+	return firingLines
+}
+
+func (c *TargetsChecker) targetsOfCompetitor(competitorID string) uint32 {
+	targets, ok := c.currentTargets[competitorID]
+	if !ok {
+		return c.defaultTargets
+	}
+
+	return targets
+}
@@ -0,0 +1,92 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/config"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseTarget(t *testing.T) {
+	target, err := ParseTarget("7")
+	assert.Nil(t, err)
+	assert.Equal(t, uint32(7), target)
+
+	_, err = ParseTarget("0")
+	assert.NotNil(t, err)
+
+	_, err = ParseTarget("hello")
+	assert.NotNil(t, err)
+}
+
+func Test_TargetsChecker(t *testing.T) {
+	t.Run("with default targets", func(t *testing.T) {
+		checker := NewTargetsChecker(config.BiathlonCompetition{FiringLines: 2})
+
+		assert.Nil(t, checker.Check(event.Event{ID: event.TargetHit, CompetitorID: "1", Extra: "5"}))
+		assert.NotNil(t, checker.Check(event.Event{ID: event.TargetHit, CompetitorID: "1", Extra: "6"}))
+	})
+
+	t.Run("with targets per firing line", func(t *testing.T) {
+		checker := NewTargetsChecker(config.BiathlonCompetition{
+			FiringLines:       2,
+			FiringLineTargets: []uint32{3, 10},
+		})
+
+		assert.Nil(t, checker.Check(event.Event{ID: event.CompetitorOnFiringRange, CompetitorID: "1", Extra: "1"}))
+		assert.Nil(t, checker.Check(event.Event{ID: event.CompetitorOnFiringRange, CompetitorID: "2", Extra: "2"}))
+
+		assert.Nil(t, checker.Check(event.Event{ID: event.TargetHit, CompetitorID: "1", Extra: "3"}))
+		assert.NotNil(t, checker.Check(event.Event{ID: event.TargetHit, CompetitorID: "1", Extra: "4"}))
+		assert.Nil(t, checker.Check(event.Event{ID: event.TargetHit, CompetitorID: "2", Extra: "10"}))
+		assert.NotNil(t, checker.Check(event.Event{ID: event.TargetHit, CompetitorID: "2", Extra: "11"}))
+
+		assert.Nil(t, checker.Check(event.Event{ID: event.TargetHit, CompetitorID: "3", Extra: "10"}))
+	})
+
+	t.Run("with firing lines visited not in order", func(t *testing.T) {
+		checker := NewTargetsChecker(config.BiathlonCompetition{
+			Laps:           3,
+			FiringLines:    2,
+			FiringLineLaps: []uint32{1, 3},
+		})
+
+		assert.NotNil(t, checker.Check(event.Event{ID: event.CompetitorOnFiringRange, CompetitorID: "1", Extra: "2"}))
+		assert.Nil(t, checker.Check(event.Event{ID: event.CompetitorOnFiringRange, CompetitorID: "1", Extra: "1"}))
+		assert.Nil(t, checker.Check(event.Event{ID: event.CompetitorEndedMainLap, CompetitorID: "1"}))
+		assert.NotNil(t, checker.Check(event.Event{ID: event.CompetitorOnFiringRange, CompetitorID: "1", Extra: "2"}))
+		assert.Nil(t, checker.Check(event.Event{ID: event.CompetitorEndedMainLap, CompetitorID: "1"}))
+
+		restored := NewTargetsChecker(config.BiathlonCompetition{
+			Laps:           3,
+			FiringLines:    2,
+			FiringLineLaps: []uint32{1, 3},
+		})
+		restored.Restore(checker.Snapshot())
+
+		assert.Nil(t, restored.Check(event.Event{ID: event.CompetitorOnFiringRange, CompetitorID: "1", Extra: "2"}))
+	})
+
+	t.Run("with more visits than firing lines", func(t *testing.T) {
+		checker := NewTargetsChecker(config.BiathlonCompetition{FiringLines: 1})
+
+		assert.Nil(t, checker.Check(event.Event{ID: event.CompetitorOnFiringRange, CompetitorID: "1", Extra: "1"}))
+		assert.ErrorIs(t, checker.Check(event.Event{ID: event.CompetitorOnFiringRange, CompetitorID: "1", Extra: "1"}), ErrBadFiringLine)
+	})
+}
+
+func Test_ParseShot(t *testing.T) {
+	shot, err := ParseShot("3 miss")
+	assert.Nil(t, err)
+	assert.Equal(t, Shot{Number: 3, Hit: false}, shot)
+
+	shot, err = ParseShot("1 hit")
+	assert.Nil(t, err)
+	assert.Equal(t, Shot{Number: 1, Hit: true}, shot)
+
+	for _, s := range []string{"", "1", "0 hit", "1 hits", "hit 1"} {
+		_, err = ParseShot(s)
+		assert.NotNil(t, err, s)
+	}
+}
@@ -0,0 +1,307 @@
+// journal contains append-only journal of incoming and outgoing events, that can be used
+// to replay events after crash.
+package journal
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"iter"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event/parser"
+)
+
+// ErrCorruptedRecord is returned if record of journal is incomplete, has wrong checksum or sequence number.
+var ErrCorruptedRecord = errors.New("corrupted record")
+
+// Record is single entry of journal. Each record is written as single line:
+//
+//	seq checksum [HH:MM:SS.sss] eventID competitorID extra
+//
+// where checksum is CRC-32 of the line without checksum in hex.
+type Record struct {
+	// Seq - sequence number of the record, starting from 1.
+	Seq   uint64
+	Event event.Event
+}
+
+// Journal implements competition.Observer. Journal appends each observed event to file
+// and syncs it to disk, so only the last record can be lost on crash.
+type Journal struct {
+	file *os.File
+	seq  uint64
+	err  error
+}
+
+// Open opens journal file with given path or creates new one. Sequence numbers of new records
+// continue the existing ones. If the last record is torn (e.g. program crashed while writing it),
+// it is truncated. Other corrupted records cause error.
+func Open(path string) (*Journal, error) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0o666)
+	if err != nil {
+		return nil, fmt.Errorf("open journal: %w", err)
+	}
+
+	j := &Journal{file: file}
+
+	s := newScanner(file)
+	for {
+		_, err = s.next()
+		if err == nil {
+			continue
+		}
+
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		if !errors.Is(err, ErrCorruptedRecord) || !s.atEnd() {
+			file.Close()
+			return nil, fmt.Errorf("read journal: %s: %w", path, err)
+		}
+
+		err = file.Truncate(s.offset)
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("truncate torn record of journal: %s: %w", path, err)
+		}
+
+		break
+	}
+
+	j.seq = s.seq
+
+	return j, nil
+}
+
+// NotifyWithEvent implements competition.Observer. It appends event to journal.
+// After the first failed write events are no longer appended, the error is returned by Err.
+func (j *Journal) NotifyWithEvent(e event.Event) {
+	if j.err != nil {
+		return
+	}
+
+	record := Record{Seq: j.seq + 1, Event: e}
+
+	_, err := j.file.WriteString(formatRecord(record))
+	if err == nil {
+		err = j.file.Sync()
+	}
+
+	if err != nil {
+		j.err = fmt.Errorf("append record %d: %w", record.Seq, err)
+		return
+	}
+
+	j.seq = record.Seq
+}
+
+// Seq returns sequence number of the last record in journal, 0 if journal is empty.
+func (j *Journal) Seq() uint64 {
+	return j.seq
+}
+
+// Err returns error of appending records to journal.
+func (j *Journal) Err() error {
+	return j.err
+}
+
+// Truncate removes records with sequence number greater than seq.
+func (j *Journal) Truncate(seq uint64) error {
+	if seq >= j.seq {
+		return nil
+	}
+
+	_, err := j.file.Seek(0, io.SeekStart)
+	if err != nil {
+		return fmt.Errorf("truncate journal: %w", err)
+	}
+
+	s := newScanner(j.file)
+	for s.seq < seq {
+		if _, err = s.next(); err != nil {
+			return fmt.Errorf("truncate journal: %w", err)
+		}
+	}
+
+	err = j.file.Truncate(s.offset)
+	if err != nil {
+		return fmt.Errorf("truncate journal: %w", err)
+	}
+
+	j.seq = seq
+
+	return nil
+}
+
+// Close journal file. Returns error of appending records, if any.
+func (j *Journal) Close() error {
+	return errors.Join(j.err, j.file.Close())
+}
+
+// Records reads records of journal from r. Records are yielded until the first error.
+func Records(r io.Reader) iter.Seq2[Record, error] {
+	return func(yield func(Record, error) bool) {
+		s := newScanner(r)
+		for {
+			record, err := s.next()
+			if errors.Is(err, io.EOF) {
+				return
+			}
+
+			if !yield(record, err) || err != nil {
+				return
+			}
+		}
+	}
+}
+
+// IncomingEvents returns incoming events from records. Outgoing events are skipped,
+// as they are generated again, when incoming events are handled.
+func IncomingEvents(records iter.Seq2[Record, error]) iter.Seq2[event.Event, error] {
+	return func(yield func(event.Event, error) bool) {
+		for record, err := range records {
+			if err != nil {
+				yield(event.Event{}, err)
+				return
+			}
+
+			if !event.ValidIncomingEventID(uint8(record.Event.ID)) {
+				continue
+			}
+
+			if !yield(record.Event, nil) {
+				return
+			}
+		}
+	}
+}
+
+func formatRecord(record Record) string {
+	line := record.Event.Line()
+
+	return fmt.Sprintf("%d %08x %s\n", record.Seq, checksum(record.Seq, line), line)
+}
+
+func checksum(seq uint64, line string) uint32 {
+	return crc32.ChecksumIEEE([]byte(strconv.FormatUint(seq, 10) + " " + line))
+}
+
+// scanner reads records one by one and tracks offset of the end of the last valid record.
+type scanner struct {
+	reader *bufio.Reader
+	offset int64
+	seq    uint64
+}
+
+func newScanner(r io.Reader) *scanner {
+	return &scanner{reader: bufio.NewReader(r)}
+}
+
+// next returns next record or io.EOF if there are no more records.
+func (s *scanner) next() (Record, error) {
+	line, err := s.reader.ReadString('\n')
+	if errors.Is(err, io.EOF) && line == "" {
+		return Record{}, io.EOF
+	}
+
+	if err != nil && !errors.Is(err, io.EOF) {
+		return Record{}, err
+	}
+
+	if !strings.HasSuffix(line, "\n") {
+		return Record{}, fmt.Errorf("%w: record %d is not complete", ErrCorruptedRecord, s.seq+1)
+	}
+
+	record, err := parseRecord(strings.TrimSuffix(line, "\n"))
+	if err != nil {
+		return Record{}, fmt.Errorf("%w: record %d: %w", ErrCorruptedRecord, s.seq+1, err)
+	}
+
+	if record.Seq != s.seq+1 {
+		return Record{}, fmt.Errorf("%w: expected sequence number %d, got: %d", ErrCorruptedRecord, s.seq+1, record.Seq)
+	}
+
+	s.seq = record.Seq
+	s.offset += int64(len(line))
+
+	return record, nil
+}
+
+// atEnd checks if there is no more data after the last read line.
+func (s *scanner) atEnd() bool {
+	_, err := s.reader.Peek(1)
+
+	return errors.Is(err, io.EOF)
+}
+
+// Amounts of space separated fields in record and in event line.
+const (
+	recordParts = 3
+	eventParts  = 4
+)
+
+func parseRecord(line string) (Record, error) {
+	split := strings.SplitN(line, " ", recordParts)
+	if len(split) < recordParts {
+		return Record{}, errors.New("not enough fields")
+	}
+
+	seq, err := strconv.ParseUint(split[0], 10, 64)
+	if err != nil {
+		return Record{}, fmt.Errorf("bad sequence number: %w", err)
+	}
+
+	sum, err := strconv.ParseUint(split[1], 16, 32)
+	if err != nil {
+		return Record{}, fmt.Errorf("bad checksum: %w", err)
+	}
+
+	if uint32(sum) != checksum(seq, split[2]) {
+		return Record{}, errors.New("checksum mismatch")
+	}
+
+	e, err := parseEvent(split[2])
+	if err != nil {
+		return Record{}, err
+	}
+
+	return Record{Seq: seq, Event: e}, nil
+}
+
+// parseEvent parses line written by event.Event.Line. Unlike parser.ParseSingleLine,
+// outgoing events are allowed and extra is not validated, as it is protected by checksum.
+func parseEvent(line string) (event.Event, error) {
+	split := strings.SplitN(line, " ", eventParts)
+	if len(split) < eventParts-1 {
+		return event.Event{}, errors.New("not enough event fields")
+	}
+
+	eventTime, err := parser.ParseTime(strings.Trim(split[0], "[]"))
+	if err != nil {
+		return event.Event{}, fmt.Errorf("bad event timestamp: %w", err)
+	}
+
+	id, err := strconv.ParseUint(split[1], 10, 8)
+	if err != nil {
+		return event.Event{}, fmt.Errorf("bad event id: %w", err)
+	}
+
+	e := event.Event{
+		Time:         eventTime,
+		ID:           event.EventID(id),
+		CompetitorID: split[2],
+	}
+
+	if len(split) == eventParts {
+		e.Extra = split[3]
+	}
+
+	return e, nil
+}
@@ -0,0 +1,145 @@
+package journal
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+func givenEvents(t *testing.T) []event.Event {
+	eventTime, err := parser.ParseTime("10:00:00.000")
+	assert.Nil(t, err)
+
+	return []event.Event{
+		{Time: eventTime, ID: event.CompetitorRegistration, CompetitorID: "1"},
+		{Time: eventTime, ID: event.StartTimeAssignment, CompetitorID: "1", Extra: "10:01:30.000"},
+		{Time: eventTime, ID: event.CompetitorDisqualified, CompetitorID: "1", Extra: "lateStart late start by 1.000 seconds"},
+		{Time: eventTime, ID: event.JuryReinstatement, CompetitorID: "1"},
+	}
+}
+
+func readRecords(t *testing.T, path string) ([]Record, error) {
+	file, err := os.Open(path)
+	assert.Nil(t, err)
+	defer file.Close()
+
+	records := make([]Record, 0)
+	for record, err := range Records(file) {
+		if err != nil {
+			return records, err
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func Test_Journal(t *testing.T) {
+	events := givenEvents(t)
+
+	path := filepath.Join(t.TempDir(), "journal")
+
+	j, err := Open(path)
+	assert.Nil(t, err)
+
+	for _, e := range events[:3] {
+		j.NotifyWithEvent(e)
+	}
+
+	assert.Equal(t, uint64(3), j.Seq())
+	assert.Nil(t, j.Close())
+
+	t.Run("continue sequence after reopen", func(t *testing.T) {
+		j, err := Open(path)
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(3), j.Seq())
+
+		j.NotifyWithEvent(events[3])
+		assert.Nil(t, j.Close())
+
+		records, err := readRecords(t, path)
+		assert.Nil(t, err)
+		assert.Len(t, records, 4)
+
+		for i, record := range records {
+			assert.Equal(t, Record{Seq: uint64(i + 1), Event: events[i]}, record)
+		}
+	})
+
+	t.Run("truncate torn record", func(t *testing.T) {
+		file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o666)
+		assert.Nil(t, err)
+		_, err = file.WriteString("5 0badc0de [10:00:00.0")
+		assert.Nil(t, err)
+		assert.Nil(t, file.Close())
+
+		_, err = readRecords(t, path)
+		assert.ErrorIs(t, err, ErrCorruptedRecord)
+
+		j, err := Open(path)
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(4), j.Seq())
+		assert.Nil(t, j.Close())
+
+		records, err := readRecords(t, path)
+		assert.Nil(t, err)
+		assert.Len(t, records, 4)
+	})
+
+	t.Run("truncate to sequence number", func(t *testing.T) {
+		j, err := Open(path)
+		assert.Nil(t, err)
+
+		assert.Nil(t, j.Truncate(2))
+		assert.Equal(t, uint64(2), j.Seq())
+
+		j.NotifyWithEvent(events[3])
+		assert.Nil(t, j.Close())
+
+		records, err := readRecords(t, path)
+		assert.Nil(t, err)
+		assert.Equal(t, []Record{
+			{Seq: 1, Event: events[0]},
+			{Seq: 2, Event: events[1]},
+			{Seq: 3, Event: events[3]},
+		}, records)
+	})
+
+	t.Run("fail on corrupted record in the middle", func(t *testing.T) {
+		data, err := os.ReadFile(path)
+		assert.Nil(t, err)
+
+		corruptedPath := filepath.Join(t.TempDir(), "journal")
+		corrupted := strings.Replace(string(data), "10:01:30.000", "10:01:31.000", 1)
+		assert.Nil(t, os.WriteFile(corruptedPath, []byte(corrupted), 0o666))
+
+		_, err = Open(corruptedPath)
+		assert.ErrorIs(t, err, ErrCorruptedRecord)
+	})
+}
+
+func Test_IncomingEvents(t *testing.T) {
+	events := givenEvents(t)
+
+	lines := strings.Builder{}
+	for i, e := range events {
+		lines.WriteString(formatRecord(Record{Seq: uint64(i + 1), Event: e}))
+	}
+
+	incoming := make([]event.Event, 0)
+	for e, err := range IncomingEvents(Records(strings.NewReader(lines.String()))) {
+		assert.Nil(t, err)
+
+		incoming = append(incoming, e)
+	}
+
+	expected := slices.Delete(slices.Clone(events), 2, 3)
+	assert.Equal(t, expected, incoming)
+}
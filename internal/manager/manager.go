@@ -0,0 +1,185 @@
+// manager contains Manager, that handles several simultaneous competitions in one process.
+package manager
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/competition"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/config"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/engine"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event/parser"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/report"
+)
+
+var (
+	// ErrUnknownCompetition is returned if there is no competition with given key.
+	ErrUnknownCompetition = errors.New("unknown competition")
+	// ErrDuplicateCompetition is returned if competition with given key is already added.
+	ErrDuplicateCompetition = errors.New("competition already exists")
+	// ErrBadCompetitionKey is returned if competition key is empty, contains spaces or path separators
+	// or starts with '['.
+	ErrBadCompetitionKey = errors.New("bad competition key")
+)
+
+// Manager holds several competitions, each with its own config and reporter, and routes events
+// to them by competition key. Each competition is handled by its own engine.Engine,
+// so methods of Manager can be called from several goroutines.
+type Manager struct {
+	mu           sync.RWMutex
+	competitions map[string]*engine.Engine
+}
+
+// New creates Manager without competitions.
+func New() *Manager {
+	return &Manager{
+		competitions: make(map[string]*engine.Engine),
+	}
+}
+
+// Add creates competition with given key and config. Observer is notified with all incoming
+// and outgoing events of this competition, it may be nil.
+func (m *Manager) Add(key string, conf config.BiathlonCompetition, observer competition.Observer) error {
+	err := CheckKey(key)
+	if err != nil {
+		return err
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, ok := m.competitions[key]; ok {
+		return fmt.Errorf("%w: '%s'", ErrDuplicateCompetition, key)
+	}
+
+	competitionEngine, err := engine.New(conf, observer)
+	if err != nil {
+		return fmt.Errorf("competition '%s': %w", key, err)
+	}
+
+	m.competitions[key] = competitionEngine
+
+	return nil
+}
+
+// Keys returns sorted keys of all competitions.
+func (m *Manager) Keys() []string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	keys := make([]string, 0, len(m.competitions))
+	for key := range m.competitions {
+		keys = append(keys, key)
+	}
+
+	slices.Sort(keys)
+
+	return keys
+}
+
+func (m *Manager) competition(key string) (*engine.Engine, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	competitionEngine, ok := m.competitions[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: '%s'", ErrUnknownCompetition, key)
+	}
+
+	return competitionEngine, nil
+}
+
+// HandleEvent handles incoming event of competition with given key, see engine.Engine.HandleEvent.
+func (m *Manager) HandleEvent(key string, e event.Event) error {
+	competitionEngine, err := m.competition(key)
+	if err != nil {
+		return err
+	}
+
+	err = competitionEngine.HandleEvent(e)
+	if err != nil {
+		return fmt.Errorf("competition '%s': %w", key, err)
+	}
+
+	return nil
+}
+
+// HandleLine parses line prefixed with competition key (see SplitKey) and handles the event.
+func (m *Manager) HandleLine(line string) error {
+	key, eventLine, err := SplitKey(line)
+	if err != nil {
+		return err
+	}
+
+	e, err := parser.ParseSingleLine(eventLine)
+	if err != nil {
+		return fmt.Errorf("competition '%s': %w", key, err)
+	}
+
+	return m.HandleEvent(key, e)
+}
+
+// Report returns report of competition with given key, see report.Reporter.MakeReport.
+func (m *Manager) Report(key string) (report.Report, error) {
+	competitionEngine, err := m.competition(key)
+	if err != nil {
+		return report.Report{}, err
+	}
+
+	return competitionEngine.Report()
+}
+
+// ProvisionalReport returns report of the current moment of competition with given key,
+// see report.Reporter.MakeProvisionalReport.
+func (m *Manager) ProvisionalReport(key string) (report.Report, error) {
+	competitionEngine, err := m.competition(key)
+	if err != nil {
+		return report.Report{}, err
+	}
+
+	return competitionEngine.ProvisionalReport()
+}
+
+// Close closes engines of all competitions, after that methods handling events
+// and making reports return engine.ErrClosed.
+func (m *Manager) Close() {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	for _, competitionEngine := range m.competitions {
+		competitionEngine.Close()
+	}
+}
+
+// CheckKey checks if the key can be used as competition key: it must be not empty,
+// must not contain spaces and must not start with '['. The key is used as name of report file,
+// so it must not contain path separators too.
+func CheckKey(key string) error {
+	if key == "" || strings.ContainsAny(key, " \t/\\") || strings.HasPrefix(key, "[") {
+		return fmt.Errorf("%w: '%s'", ErrBadCompetitionKey, key)
+	}
+
+	return nil
+}
+
+// SplitKey splits line of events file with several competitions into competition key and event line.
+// Such line is event line prefixed with the key and space:
+//
+//	men [09:05:59.867] 1 1
+func SplitKey(line string) (string, string, error) {
+	key, eventLine, found := strings.Cut(line, " ")
+	if !found {
+		return "", "", fmt.Errorf("%w: no competition key in line '%s'", ErrBadCompetitionKey, line)
+	}
+
+	err := CheckKey(key)
+	if err != nil {
+		return "", "", err
+	}
+
+	return key, eventLine, nil
+}
@@ -0,0 +1,209 @@
+package manager
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/competition"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/config"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/engine"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/report"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/simulator"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	menConfig = config.BiathlonCompetition{
+		Laps:        3,
+		LapLen:      3300,
+		PenaltyLen:  150,
+		FiringLines: 2,
+		Start:       "10:00:00.000",
+		StartDelta:  "00:00:30",
+	}
+	womenConfig = config.BiathlonCompetition{
+		Laps:        2,
+		LapLen:      2500,
+		PenaltyLen:  150,
+		FiringLines: 1,
+		Start:       "10:00:00.000",
+		StartDelta:  "00:01:00",
+	}
+)
+
+// competitorEvents returns simulated events of given amount of competitors grouped by competitor.
+func competitorEvents(t *testing.T, conf config.BiathlonCompetition, competitors int) [][]event.Event {
+	t.Helper()
+
+	params := simulator.DefaultParams()
+	params.Competitors = competitors
+
+	events, err := simulator.GenerateByCompetitor(conf, params)
+	assert.Nil(t, err)
+
+	return events
+}
+
+func expectedReport(t *testing.T, conf config.BiathlonCompetition, competitors int) report.Report {
+	t.Helper()
+
+	reporter := report.NewReporter(conf)
+	biathlon, err := competition.NewBiathlon(conf, reporter)
+	assert.Nil(t, err)
+
+	for _, competitor := range competitorEvents(t, conf, competitors) {
+		for _, e := range competitor {
+			biathlon.HandleEvent(e)
+		}
+	}
+
+	return reporter.MakeReport()
+}
+
+func Test_Manager(t *testing.T) {
+	const competitors = 20
+
+	configs := map[string]config.BiathlonCompetition{
+		"men":   menConfig,
+		"women": womenConfig,
+	}
+
+	t.Run("competitions are handled concurrently and independently", func(t *testing.T) {
+		manager := New()
+		defer manager.Close()
+
+		for key, conf := range configs {
+			assert.Nil(t, manager.Add(key, conf, nil))
+		}
+
+		assert.Equal(t, []string{"men", "women"}, manager.Keys())
+
+		wg := sync.WaitGroup{}
+
+		for key, conf := range configs {
+			for _, competitor := range competitorEvents(t, conf, competitors) {
+				wg.Add(1)
+
+				go func() {
+					defer wg.Done()
+
+					for _, e := range competitor {
+						assert.Nil(t, manager.HandleEvent(key, e))
+					}
+				}()
+			}
+		}
+
+		wg.Wait()
+
+		for key, conf := range configs {
+			actual, err := manager.Report(key)
+			assert.Nil(t, err)
+			assert.Equal(t, expectedReport(t, conf, competitors), actual, key)
+		}
+	})
+
+	t.Run("lines are routed by competition key", func(t *testing.T) {
+		manager := New()
+		defer manager.Close()
+
+		for key, conf := range configs {
+			assert.Nil(t, manager.Add(key, conf, nil))
+		}
+
+		for key, conf := range configs {
+			for _, competitor := range competitorEvents(t, conf, competitors) {
+				for _, e := range competitor {
+					assert.Nil(t, manager.HandleLine(key+" "+e.Line()))
+				}
+			}
+		}
+
+		for key, conf := range configs {
+			actual, err := manager.Report(key)
+			assert.Nil(t, err)
+			assert.Equal(t, expectedReport(t, conf, competitors), actual, key)
+		}
+	})
+
+	t.Run("errors", func(t *testing.T) {
+		manager := New()
+
+		assert.Nil(t, manager.Add("men", menConfig, nil))
+		assert.ErrorIs(t, manager.Add("men", womenConfig, nil), ErrDuplicateCompetition)
+		assert.ErrorIs(t, manager.Add("", womenConfig, nil), ErrBadCompetitionKey)
+		assert.ErrorIs(t, manager.Add("reports/women", womenConfig, nil), ErrBadCompetitionKey)
+		assert.NotNil(t, manager.Add("women", config.BiathlonCompetition{}, nil))
+		assert.Equal(t, []string{"men"}, manager.Keys())
+
+		e := competitorEvents(t, womenConfig, 1)[0][0]
+		assert.ErrorIs(t, manager.HandleEvent("women", e), ErrUnknownCompetition)
+		assert.ErrorIs(t, manager.HandleLine("women "+e.Line()), ErrUnknownCompetition)
+		assert.ErrorIs(t, manager.HandleLine(e.Line()), ErrBadCompetitionKey)
+		assert.NotNil(t, manager.HandleLine("men [10:00:00.000] 1"))
+
+		_, err := manager.ProvisionalReport("women")
+		assert.ErrorIs(t, err, ErrUnknownCompetition)
+
+		manager.Close()
+
+		assert.ErrorIs(t, manager.HandleEvent("men", e), engine.ErrClosed)
+
+		_, err = manager.Report("men")
+		assert.ErrorIs(t, err, engine.ErrClosed)
+	})
+}
+
+func Test_SplitKey(t *testing.T) {
+	type testcase struct {
+		line              string
+		expectedKey       string
+		expectedEventLine string
+		expectedErr       error
+	}
+
+	cases := []testcase{
+		{
+			line:              "men [09:05:59.867] 1 1",
+			expectedKey:       "men",
+			expectedEventLine: "[09:05:59.867] 1 1",
+		},
+		{
+			line:              "women-15km [09:05:59.867] 11 1 Lost in the forest",
+			expectedKey:       "women-15km",
+			expectedEventLine: "[09:05:59.867] 11 1 Lost in the forest",
+		},
+		{
+			line:        "[09:05:59.867] 1 1",
+			expectedErr: ErrBadCompetitionKey,
+		},
+		{
+			line:        " [09:05:59.867] 1 1",
+			expectedErr: ErrBadCompetitionKey,
+		},
+		{
+			line:        "men",
+			expectedErr: ErrBadCompetitionKey,
+		},
+		{
+			line:        "../men [09:05:59.867] 1 1",
+			expectedErr: ErrBadCompetitionKey,
+		},
+		{
+			line:        `men\15km [09:05:59.867] 1 1`,
+			expectedErr: ErrBadCompetitionKey,
+		},
+	}
+
+	for i, c := range cases {
+		t.Run(fmt.Sprintf("case %d: %s", i+1, c.line), func(t *testing.T) {
+			key, eventLine, err := SplitKey(c.line)
+
+			assert.ErrorIs(t, err, c.expectedErr)
+			assert.Equal(t, c.expectedKey, key)
+			assert.Equal(t, c.expectedEventLine, eventLine)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package report
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
+)
+
+// checkpoint is the last passed point of the course, where time of the competitor was recorded.
+type checkpoint struct {
+	Time time.Time
+	ID   event.EventID
+	// LapsCompleted - amount of completed main laps at the moment of checkpoint.
+	LapsCompleted uint32
+	// FiringLine - number of firing line from CompetitorOnFiringRange event.
+	FiringLine string
+}
+
+func (c checkpoint) String() string {
+	return fmt.Sprintf("%s at %s", checkpointName(c.ID), c.Time.Format(event.TimeFormat))
+}
+
+// Location describes where the competitor is after the checkpoint: "on lap 2", "at range 1" or "in penalty laps".
+func (c checkpoint) Location() string {
+	switch c.ID {
+	case event.CompetitorOnFiringRange:
+		return "at range " + c.FiringLine
+	case event.CompetitorEnterPenaltyLaps:
+		return "in penalty laps"
+	default:
+		return fmt.Sprintf("on lap %d", c.LapsCompleted+1)
+	}
+}
+
+// checkpointNames contains descriptions of events, that are checkpoints. It is indexed by event id,
+// description is empty for other events.
+var checkpointNames = [...]string{
+	event.CompetitorStarted:          "started",
+	event.CompetitorOnFiringRange:    "arrived at firing range",
+	event.CompetitorLeftFiringRange:  "left firing range",
+	event.CompetitorEnterPenaltyLaps: "entered penalty laps",
+	event.CompetitorLeftPenaltyLaps:  "left penalty laps",
+	event.CompetitorEndedMainLap:     "ended main lap",
+}
+
+// checkpointReporter is responsible for tracking the last checkpoint of the competitor,
+// which is shown for competitors still on course in provisional report.
+type checkpointReporter struct {
+	last *checkpoint
+}
+
+func newCheckpointReporter() *checkpointReporter {
+	return &checkpointReporter{}
+}
+
+func (c *checkpointReporter) NotifyWithEvent(e event.Event) {
+	if checkpointName(e.ID) == "" {
+		return
+	}
+
+	if c.last == nil {
+		c.last = &checkpoint{}
+	}
+
+	next := checkpoint{Time: e.Time, ID: e.ID, LapsCompleted: c.last.LapsCompleted}
+
+	switch e.ID {
+	case event.CompetitorOnFiringRange:
+		next.FiringLine = e.Extra
+	case event.CompetitorEndedMainLap:
+		next.LapsCompleted += 1
+	}
+
+	*c.last = next
+}
+
+// checkpointName returns description of checkpoint, empty if the event is not a checkpoint.
+func checkpointName(id event.EventID) string {
+	if int(id) >= len(checkpointNames) {
+		return ""
+	}
+
+	return checkpointNames[id]
+}
+
+// GetCheckpoint returns the last checkpoint, false if the competitor has not started.
+func (c *checkpointReporter) GetCheckpoint() (checkpoint, bool) {
+	if c.last == nil {
+		return checkpoint{}, false
+	}
+
+	return *c.last, true
+}
@@ -0,0 +1,41 @@
+package report
+
+import (
+	"testing"
+	"time"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_checkpointReporter(t *testing.T) {
+	c := newCheckpointReporter()
+
+	_, ok := c.GetCheckpoint()
+	assert.False(t, ok)
+
+	startTime := time.Date(0, time.January, 1, 10, 0, 0, 0, time.UTC)
+
+	c.NotifyWithEvent(event.Event{Time: startTime, ID: event.CompetitorStarted})
+	c.NotifyWithEvent(event.Event{Time: startTime.Add(time.Minute * 10), ID: event.CompetitorOnFiringRange, Extra: "1"})
+	c.NotifyWithEvent(event.Event{Time: startTime.Add(time.Minute * 11), ID: event.TargetHit, Extra: "1"})
+
+	last, ok := c.GetCheckpoint()
+	assert.True(t, ok)
+	assert.Equal(t, checkpoint{Time: startTime.Add(time.Minute * 10), ID: event.CompetitorOnFiringRange, FiringLine: "1"}, last)
+	assert.Equal(t, "arrived at firing range at 10:10:00.000", last.String())
+	assert.Equal(t, "at range 1", last.Location())
+
+	c.NotifyWithEvent(event.Event{Time: startTime.Add(time.Minute * 12), ID: event.CompetitorLeftFiringRange})
+	c.NotifyWithEvent(event.Event{Time: startTime.Add(time.Minute * 13), ID: event.CompetitorEnterPenaltyLaps})
+
+	last, _ = c.GetCheckpoint()
+	assert.Equal(t, "in penalty laps", last.Location())
+
+	c.NotifyWithEvent(event.Event{Time: startTime.Add(time.Minute * 14), ID: event.CompetitorLeftPenaltyLaps})
+	c.NotifyWithEvent(event.Event{Time: startTime.Add(time.Minute * 15), ID: event.CompetitorEndedMainLap})
+
+	last, _ = c.GetCheckpoint()
+	assert.Equal(t, uint32(1), last.LapsCompleted)
+	assert.Equal(t, "on lap 2", last.Location())
+}
@@ -0,0 +1,50 @@
+package report
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
+)
+
+// JuryCorrectionsTitle is the title of text report section with jury corrections.
+const JuryCorrectionsTitle = "Jury corrections"
+
+// corrections returns jury corrections of all competitors in chronological order.
+func (report Report) corrections() []event.Event {
+	res := make([]event.Event, 0)
+	for _, record := range report {
+		res = append(res, record.corrections...)
+	}
+
+	slices.SortStableFunc(res, func(first, second event.Event) int {
+		return cmp.Or(first.Time.Compare(second.Time), strings.Compare(first.CompetitorID, second.CompetitorID))
+	})
+
+	return res
+}
+
+// juryCorrections formats audit of jury corrections, each correction on separate line.
+// Returns empty string if there were no corrections.
+// Example of formatted line:
+//
+//	[10:45:00.000] The jury added time penalty to competitor(1): 00:02:00 missed penalty lap
+func (report Report) juryCorrections() string {
+	corrections := report.corrections()
+	if len(corrections) == 0 {
+		return ""
+	}
+
+	builder := strings.Builder{}
+
+	_, _ = builder.WriteString(JuryCorrectionsTitle)
+	_, _ = builder.WriteString("\n")
+
+	for _, correction := range corrections {
+		_, _ = builder.WriteString(correction.String())
+		_, _ = builder.WriteString("\n")
+	}
+
+	return builder.String()
+}
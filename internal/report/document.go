@@ -0,0 +1,124 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/config"
+)
+
+// Format of written report.
+type Format string
+
+// constants define supported report formats.
+const (
+	// FormatText is human-readable format of report.
+	FormatText Format = "text"
+	// FormatJSON is machine-readable format of report.
+	FormatJSON Format = "json"
+)
+
+// ParseFormat checks if the given string is a supported report format.
+func ParseFormat(s string) (Format, error) {
+	switch format := Format(s); format {
+	case FormatText, FormatJSON:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unsupported report format: %s", s)
+	}
+}
+
+// Document is a complete report of competition, that contains Report and
+// rankings required by config.
+type Document struct {
+	report     Report
+	categories []string
+	teamRules  *config.TeamRanking
+}
+
+// NewDocument creates Document from given Report with rankings configured in conf.
+func NewDocument(report Report, conf config.BiathlonCompetition) Document {
+	return Document{
+		report:     report,
+		categories: conf.Categories,
+		teamRules:  conf.Teams,
+	}
+}
+
+// Write Document in given format to w.
+func (d Document) Write(w io.Writer, format Format) error {
+	switch format {
+	case FormatText:
+		return d.writeText(w)
+	case FormatJSON:
+		return d.writeJSON(w)
+	default:
+		return fmt.Errorf("unsupported report format: %s", format)
+	}
+}
+
+func (d Document) writeText(w io.Writer) error {
+	var err error
+
+	if len(d.categories) > 0 {
+		_, err = fmt.Fprint(w, d.report.Rankings(d.categories))
+	} else {
+		sorted := slices.Clone(d.report)
+		sorted.Sort()
+
+		_, err = fmt.Fprint(w, sorted)
+	}
+
+	if err != nil {
+		return err
+	}
+
+	sections := make([]string, 0)
+	if d.teamRules != nil {
+		sections = append(sections, d.report.TeamRanking(*d.teamRules).String())
+	}
+
+	sections = append(sections, d.report.shootingStages(), d.report.positions(), d.report.juryCorrections())
+
+	for _, section := range sections {
+		if section == "" {
+			continue
+		}
+
+		if _, err = fmt.Fprint(w, "\n", section); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d Document) writeJSON(w io.Writer) error {
+	rankings := d.report.Rankings(d.categories)
+
+	doc := JSONDocument{
+		Results: rankings[0].jsonResults(),
+	}
+
+	for _, ranking := range rankings[1:] {
+		doc.Rankings = append(doc.Rankings, JSONRanking{
+			Title:   ranking.Title,
+			Results: ranking.jsonResults(),
+		})
+	}
+
+	if d.teamRules != nil {
+		doc.Teams = d.report.TeamRanking(*d.teamRules).jsonResults()
+	}
+
+	for _, correction := range d.report.corrections() {
+		doc.Corrections = append(doc.Corrections, jsonCorrection(correction))
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(doc)
+}
@@ -0,0 +1,175 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/config"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_ParseFormat(t *testing.T) {
+	format, err := ParseFormat("json")
+	assert.Nil(t, err)
+	assert.Equal(t, FormatJSON, format)
+
+	_, err = ParseFormat("xml")
+	assert.NotNil(t, err)
+}
+
+func Test_Document_Write(t *testing.T) {
+	givenReport := Report([]reportRecord{
+		{
+			finalState:   StatusDNF,
+			competitorID: "1",
+			category:     "men",
+			mainLapsInfo: []mainLapInfo{{Interval: time.Minute, Speed: 1.5}, {}},
+			shootingInfo: shootingInfo{TotalTargets: 10, TotalHitTargets: 4},
+		},
+		{
+			totalTime:    time.Minute * 30,
+			finalState:   StatusFinished,
+			competitorID: "2",
+			category:     "men",
+			team:         "RUS",
+			mainLapsInfo: make([]mainLapInfo, 2),
+		},
+	})
+
+	conf := config.BiathlonCompetition{
+		Categories: []string{"men"},
+		Teams:      &config.TeamRanking{BestN: 1},
+	}
+
+	t.Run("in text format", func(t *testing.T) {
+		buf := bytes.Buffer{}
+
+		err := NewDocument(givenReport, config.BiathlonCompetition{}).Write(&buf, FormatText)
+
+		assert.Nil(t, err)
+		assert.Equal(t,
+			"[00:30:00.000] 2 [{,}, {,}] {00:00:00.000, 0.000} 0/0\n"+
+				"[NotFinished] 1 [{00:01:00.000, 1.500}, {,}] {00:00:00.000, 0.000} 4/10\n",
+			buf.String())
+	})
+
+	t.Run("in json format", func(t *testing.T) {
+		buf := bytes.Buffer{}
+
+		err := NewDocument(givenReport, conf).Write(&buf, FormatJSON)
+		assert.Nil(t, err)
+
+		doc := JSONDocument{}
+		err = json.Unmarshal(buf.Bytes(), &doc)
+		assert.Nil(t, err)
+
+		expectedResults := []JSONResult{
+			{
+				Place:        1,
+				CompetitorID: "2",
+				Category:     "men",
+				Team:         "RUS",
+				Status:       StatusFinished,
+				TotalTime:    "00:30:00.000",
+				Gap:          "00:00:00.000",
+				Laps:         []JSONLap{{}, {}},
+				PenaltyTime:  "00:00:00.000",
+			},
+			{
+				CompetitorID: "1",
+				Category:     "men",
+				Status:       StatusDNF,
+				Laps:         []JSONLap{{Time: "00:01:00.000", Speed: 1.5}, {}},
+				PenaltyTime:  "00:00:00.000",
+				Hits:         4,
+				Shots:        10,
+			},
+		}
+
+		assert.Equal(t, JSONDocument{
+			Results:  expectedResults,
+			Rankings: []JSONRanking{{Title: "men", Results: expectedResults}},
+			Teams: []JSONTeamResult{{
+				Place:       1,
+				Team:        "RUS",
+				TotalTime:   "00:30:00.000",
+				Gap:         "00:00:00.000",
+				Competitors: []string{"2"},
+			}},
+		}, doc)
+	})
+}
+
+func Test_Document_Write_withCorrectionsAndDisqualification(t *testing.T) {
+	correctionTime := time.Date(0, time.January, 1, 11, 0, 0, 0, time.UTC)
+
+	givenReport := Report([]reportRecord{
+		{
+			totalTime:    time.Minute * 32,
+			finalState:   StatusFinished,
+			competitorID: "1",
+			corrections: []event.Event{
+				{Time: correctionTime.Add(time.Minute), ID: event.JuryTimePenalty, CompetitorID: "1", Extra: "00:02:00 missed penalty lap"},
+			},
+		},
+		{
+			finalState:       StatusDSQ,
+			competitorID:     "2",
+			disqualification: event.Disqualification{Reason: event.ReasonJuryDecision, Text: "jury decision: unsportsmanlike conduct"},
+			corrections: []event.Event{
+				{Time: correctionTime, ID: event.JuryDisqualification, CompetitorID: "2", Extra: "unsportsmanlike conduct"},
+			},
+		},
+	})
+
+	t.Run("in text format", func(t *testing.T) {
+		buf := bytes.Buffer{}
+
+		err := NewDocument(givenReport, config.BiathlonCompetition{}).Write(&buf, FormatText)
+
+		assert.Nil(t, err)
+		assert.Equal(t,
+			"[00:32:00.000] 1 [] {00:00:00.000, 0.000} 0/0\n"+
+				"[DSQ] 2 [] {00:00:00.000, 0.000} 0/0 (jury decision: unsportsmanlike conduct)\n"+
+				"\n"+
+				"Jury corrections\n"+
+				"[11:00:00.000] The jury disqualified the competitor(2): unsportsmanlike conduct\n"+
+				"[11:01:00.000] The jury added time penalty to competitor(1): 00:02:00 missed penalty lap\n",
+			buf.String())
+	})
+
+	t.Run("in json format", func(t *testing.T) {
+		buf := bytes.Buffer{}
+
+		err := NewDocument(givenReport, config.BiathlonCompetition{}).Write(&buf, FormatJSON)
+		assert.Nil(t, err)
+
+		doc := JSONDocument{}
+		assert.Nil(t, json.Unmarshal(buf.Bytes(), &doc))
+
+		assert.Equal(t, StatusDSQ, doc.Results[1].Status)
+		assert.Equal(t, &JSONDisqualification{
+			Reason: string(event.ReasonJuryDecision),
+			Text:   "jury decision: unsportsmanlike conduct",
+		}, doc.Results[1].Disqualification)
+
+		assert.Equal(t, []JSONCorrection{
+			{
+				Time:         "11:00:00.000",
+				CompetitorID: "2",
+				Type:         CorrectionDisqualification,
+				Reason:       "unsportsmanlike conduct",
+			},
+			{
+				Time:         "11:01:00.000",
+				CompetitorID: "1",
+				Type:         CorrectionTimePenalty,
+				Duration:     "00:02:00.000",
+				Reason:       "missed penalty lap",
+			},
+		}, doc.Corrections)
+	})
+}
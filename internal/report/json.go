@@ -0,0 +1,268 @@
+package report
+
+import (
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event/parser"
+)
+
+// JSONDocument is machine-readable representation of Document.
+// All durations are formatted as HH:MM:SS.sss.
+type JSONDocument struct {
+	// Results of all competitors. Finished competitors are sorted by place.
+	Results []JSONResult `json:"results"`
+	// Rankings for each category from config.
+	Rankings []JSONRanking `json:"rankings,omitempty"`
+	// Teams ranking, if it is configured.
+	Teams []JSONTeamResult `json:"teams,omitempty"`
+	// Corrections - jury corrections in chronological order.
+	Corrections []JSONCorrection `json:"corrections,omitempty"`
+}
+
+// JSONRanking is machine-readable representation of Ranking.
+type JSONRanking struct {
+	Title   string       `json:"title"`
+	Results []JSONResult `json:"results"`
+}
+
+// JSONResult is machine-readable representation of single competitor result.
+type JSONResult struct {
+	// Place of the competitor, zero if competitor is not ranked.
+	Place        int    `json:"place,omitempty"`
+	CompetitorID string `json:"competitorId"`
+	Category     string `json:"category,omitempty"`
+	Team         string `json:"team,omitempty"`
+	Status       Status `json:"status"`
+	// TotalTime and Gap to the leader are present only for finished competitors.
+	TotalTime string    `json:"totalTime,omitempty"`
+	Gap       string    `json:"gap,omitempty"`
+	Laps      []JSONLap `json:"laps"`
+	// PenaltyTime - time spent on penalty laps.
+	PenaltyTime string `json:"penaltyTime"`
+	// PenaltySpeed - average speed on penalty laps.
+	PenaltySpeed float64 `json:"penaltySpeed"`
+	Hits         uint32  `json:"hits"`
+	Shots        uint32  `json:"shots"`
+	// Disqualification is present only for disqualified competitor.
+	Disqualification *JSONDisqualification `json:"disqualification,omitempty"`
+	// Checkpoint is present only for competitor still on course in provisional report.
+	Checkpoint *JSONCheckpoint `json:"checkpoint,omitempty"`
+	// Location of competitor still on course in provisional report, e.g. "on lap 2" or "at range 1".
+	Location string `json:"location,omitempty"`
+	// ProjectedTime - total time of competitor still on course, if the average speed is kept.
+	ProjectedTime string `json:"projectedTime,omitempty"`
+	// Stages - shot-level analysis of each visited firing line, present only if shots were reported.
+	Stages []JSONStage `json:"stages,omitempty"`
+	// Positions - results by shooting position, present only if positions are configured.
+	Positions []JSONPosition `json:"positions,omitempty"`
+}
+
+// JSONPosition is machine-readable representation of results in single shooting position.
+type JSONPosition struct {
+	Position string `json:"position"`
+	Hits     uint32 `json:"hits"`
+	Targets  uint32 `json:"targets"`
+	// Accuracy - percentage of hit targets.
+	Accuracy float64 `json:"accuracy"`
+	// RangeTime - time spent on firing lines with this position.
+	RangeTime string `json:"rangeTime"`
+}
+
+// JSONDisqualification is machine-readable representation of disqualification reason.
+type JSONDisqualification struct {
+	// Reason code, one of event.DisqualificationReason values. Empty if the reason is unknown.
+	Reason string `json:"reason,omitempty"`
+	Text   string `json:"text,omitempty"`
+}
+
+// JSONCheckpoint is machine-readable representation of the last checkpoint of the competitor.
+type JSONCheckpoint struct {
+	Time string `json:"time"`
+	// Event - description of the checkpoint, e.g. "ended main lap".
+	Event string `json:"event"`
+}
+
+// JSONStage is machine-readable representation of shot-level analysis of single shooting stage.
+// Fields are empty if no shots were reported on the stage.
+type JSONStage struct {
+	// FirstShotTime - time between arrival on firing line and the first shot.
+	FirstShotTime string `json:"firstShotTime,omitempty"`
+	// Rhythm - average time between consecutive shots.
+	Rhythm      string   `json:"rhythm,omitempty"`
+	Shots       uint32   `json:"shots"`
+	MissedShots []uint32 `json:"missedShots"`
+}
+
+// JSONLap is machine-readable representation of main lap info.
+// Fields are empty if the lap was not completed.
+type JSONLap struct {
+	Time  string  `json:"time,omitempty"`
+	Speed float64 `json:"speed,omitempty"`
+}
+
+// Types of jury corrections used in JSONCorrection.
+const (
+	CorrectionTimePenalty      = "timePenalty"
+	CorrectionTimeBonus        = "timeBonus"
+	CorrectionReinstatement    = "reinstatement"
+	CorrectionDisqualification = "disqualification"
+)
+
+// JSONCorrection is machine-readable representation of jury correction.
+type JSONCorrection struct {
+	Time         string `json:"time"`
+	CompetitorID string `json:"competitorId"`
+	Type         string `json:"type"`
+	// Duration of time penalty or bonus.
+	Duration string `json:"duration,omitempty"`
+	Reason   string `json:"reason,omitempty"`
+}
+
+// JSONTeamResult is machine-readable representation of team result.
+type JSONTeamResult struct {
+	// Place of the team, zero if the team is not ranked.
+	Place       int      `json:"place,omitempty"`
+	Team        string   `json:"team"`
+	TotalTime   string   `json:"totalTime,omitempty"`
+	Gap         string   `json:"gap,omitempty"`
+	Competitors []string `json:"competitors"`
+}
+
+func (ranking Ranking) jsonResults() []JSONResult {
+	places := ranking.places()
+
+	results := make([]JSONResult, 0, len(ranking.Report))
+	for i, record := range ranking.Report {
+		result := record.jsonResult()
+
+		if places[i] != 0 {
+			result.Place = places[i]
+			result.Gap = formatDuration(record.totalTime - ranking.Report[0].totalTime)
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func (rr reportRecord) jsonResult() JSONResult {
+	result := JSONResult{
+		CompetitorID: rr.competitorID,
+		Category:     rr.category,
+		Team:         rr.team,
+		Status:       rr.finalState,
+		Laps:         make([]JSONLap, 0, len(rr.mainLapsInfo)),
+		PenaltyTime:  formatDuration(rr.shootingInfo.TimeSpentOnPenaltyLaps),
+		PenaltySpeed: rr.shootingInfo.AverageSpeedOnPenaltyLaps,
+		Hits:         rr.shootingInfo.TotalHitTargets,
+		Shots:        rr.shootingInfo.TotalTargets,
+	}
+
+	if rr.finalState == StatusFinished {
+		result.TotalTime = formatDuration(rr.totalTime)
+	}
+
+	if rr.finalState == StatusDSQ {
+		result.Disqualification = &JSONDisqualification{
+			Reason: string(rr.disqualification.Reason),
+			Text:   rr.disqualification.Text,
+		}
+	}
+
+	if rr.finalState == StatusRunning && rr.checkpoint.ID != 0 {
+		result.Checkpoint = &JSONCheckpoint{
+			Time:  rr.checkpoint.Time.Format(event.TimeFormat),
+			Event: checkpointName(rr.checkpoint.ID),
+		}
+		result.Location = rr.checkpoint.Location()
+	}
+
+	if rr.finalState == StatusRunning && rr.projectedTime != 0 {
+		result.ProjectedTime = formatDuration(rr.projectedTime)
+	}
+
+	if rr.shootingInfo.hasShots() {
+		for _, stage := range rr.shootingInfo.Stages {
+			jsonStage := JSONStage{Shots: stage.Shots, MissedShots: stage.MissedShots}
+			if stage.Shots != 0 {
+				jsonStage.FirstShotTime = formatDuration(stage.FirstShotTime)
+				jsonStage.Rhythm = formatDuration(stage.Rhythm)
+			}
+
+			result.Stages = append(result.Stages, jsonStage)
+		}
+	}
+
+	for _, position := range rr.shootingInfo.Positions {
+		result.Positions = append(result.Positions, JSONPosition{
+			Position:  position.Position,
+			Hits:      position.Hits,
+			Targets:   position.Targets,
+			Accuracy:  position.Accuracy(),
+			RangeTime: formatDuration(position.RangeTime),
+		})
+	}
+
+	for _, info := range rr.mainLapsInfo {
+		lap := JSONLap{}
+		if info.Interval != 0 {
+			lap.Time = formatDuration(info.Interval)
+			lap.Speed = info.Speed
+		}
+
+		result.Laps = append(result.Laps, lap)
+	}
+
+	return result
+}
+
+func (ranking TeamRanking) jsonResults() []JSONTeamResult {
+	results := make([]JSONTeamResult, 0, len(ranking))
+	for _, record := range ranking {
+		result := JSONTeamResult{
+			Team:        record.team,
+			Competitors: make([]string, 0, len(record.counted)),
+		}
+
+		if record.complete {
+			result.Place = record.place
+			result.TotalTime = formatDuration(record.totalTime)
+			result.Gap = formatDuration(record.totalTime - ranking[0].totalTime)
+		}
+
+		for _, competitor := range record.counted {
+			result.Competitors = append(result.Competitors, competitor.competitorID)
+		}
+
+		results = append(results, result)
+	}
+
+	return results
+}
+
+func jsonCorrection(e event.Event) JSONCorrection {
+	correction := JSONCorrection{
+		Time:         e.Time.Format(event.TimeFormat),
+		CompetitorID: e.CompetitorID,
+		Reason:       e.Extra,
+	}
+
+	switch e.ID {
+	case event.JuryTimePenalty:
+		correction.Type = CorrectionTimePenalty
+	case event.JuryTimeBonus:
+		correction.Type = CorrectionTimeBonus
+	case event.JuryReinstatement:
+		correction.Type = CorrectionReinstatement
+	case event.JuryDisqualification:
+		correction.Type = CorrectionDisqualification
+	}
+
+	if e.ID == event.JuryTimePenalty || e.ID == event.JuryTimeBonus {
+		timeCorrection, _ := parser.ParseTimeCorrection(e.Extra)
+		correction.Duration = formatDuration(timeCorrection.Duration)
+		correction.Reason = timeCorrection.Reason
+	}
+
+	return correction
+}
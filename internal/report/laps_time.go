@@ -9,19 +9,23 @@ import (
 )
 
 // lapsTimeReporter is responsible for calculating time and speed for each main lap.
+// Laps of disqualified competitor are still counted, as the jury can reinstate the competitor.
 type lapsTimeReporter struct {
 	lapsCompleted uint32
 	lapStart      time.Time
-	lapLen        uint32
+	lapLens       []uint32
 	lapTimes      []time.Duration
 	stop          bool
+	// started - competitor has started, before start the competitor can not stop racing.
+	started bool
 }
 
-func newLapsTimeReporter(laps uint32, lapLen uint32) *lapsTimeReporter {
+// newLapsTimeReporter creates lapsTimeReporter for laps with given lengths.
+func newLapsTimeReporter(lapLens []uint32) *lapsTimeReporter {
 	return &lapsTimeReporter{
 		lapsCompleted: 0,
-		lapLen:        lapLen,
-		lapTimes:      make([]time.Duration, laps),
+		lapLens:       lapLens,
+		lapTimes:      make([]time.Duration, len(lapLens)),
 	}
 }
 
@@ -36,7 +40,8 @@ func (lt *lapsTimeReporter) NotifyWithEvent(e event.Event) {
 	}
 
 	if e.ID == event.CompetitorEndedMainLap {
-		lapTime := e.Time.Sub(lt.lapStart)
+		// lap ended before scheduled start (e.g. after early start) has zero time instead of negative.
+		lapTime := max(e.Time.Sub(lt.lapStart), 0)
 		lt.lapTimes[lt.lapsCompleted] = lapTime
 		lt.lapsCompleted += 1
 
@@ -49,7 +54,12 @@ func (lt *lapsTimeReporter) NotifyWithEvent(e event.Event) {
 		return
 	}
 
-	if e.ID == event.CompetitorDisqualified || e.ID == event.CompetitorCannotContinue {
+	if e.ID == event.CompetitorStarted {
+		lt.started = true
+	}
+
+	// the same as referees and totalTimeReporter, competitor stops racing only after start.
+	if lt.started && (e.ID == event.CompetitorCannotContinue || e.ID == event.CompetitorLapped) {
 		lt.stop = true
 	}
 }
@@ -82,8 +92,33 @@ func (lt *lapsTimeReporter) GetLapTimesAndSpeed() []mainLapInfo {
 		}
 
 		result[i].Interval = interval
-		result[i].Speed = float64(lt.lapLen) / interval.Seconds()
+		result[i].Speed = float64(lt.lapLens[i]) / interval.Seconds()
 	}
 
 	return result
 }
+
+// GetProjectedTime returns time of completing all main laps, if the competitor keeps
+// the average speed of completed laps. Returns false if no laps are completed.
+func (lt *lapsTimeReporter) GetProjectedTime() (time.Duration, bool) {
+	var (
+		elapsed   time.Duration
+		covered   uint32
+		remaining uint32
+	)
+
+	for i, lapLen := range lt.lapLens {
+		if uint32(i) < lt.lapsCompleted {
+			elapsed += lt.lapTimes[i]
+			covered += lapLen
+		} else {
+			remaining += lapLen
+		}
+	}
+
+	if lt.lapsCompleted == 0 || covered == 0 {
+		return 0, false
+	}
+
+	return elapsed + time.Duration(float64(elapsed)*float64(remaining)/float64(covered)), true
+}
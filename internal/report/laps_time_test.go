@@ -1,6 +1,7 @@
 package report
 
 import (
+	"slices"
 	"testing"
 	"time"
 
@@ -30,7 +31,7 @@ func Test_lapsTimeReporter(t *testing.T) {
 	t.Run("with all laps completed", func(t *testing.T) {
 		t.Parallel()
 
-		lt := newLapsTimeReporter(lapsCount-1, lapLen)
+		lt := newLapsTimeReporter(slices.Repeat([]uint32{lapLen}, int(lapsCount-1)))
 
 		lt.NotifyWithEvent(event.Event{
 			ID:    event.StartTimeAssignment,
@@ -61,7 +62,7 @@ func Test_lapsTimeReporter(t *testing.T) {
 
 		completedLaps := 1
 
-		lt := newLapsTimeReporter(lapsCount, lapLen)
+		lt := newLapsTimeReporter(slices.Repeat([]uint32{lapLen}, int(lapsCount)))
 
 		lt.NotifyWithEvent(event.Event{
 			ID:    event.StartTimeAssignment,
@@ -90,7 +91,7 @@ func Test_lapsTimeReporter(t *testing.T) {
 	t.Run("with more laps completed", func(t *testing.T) {
 		t.Parallel()
 
-		lt := newLapsTimeReporter(lapsCount-1, lapLen)
+		lt := newLapsTimeReporter(slices.Repeat([]uint32{lapLen}, int(lapsCount-1)))
 
 		lt.NotifyWithEvent(event.Event{
 			ID:    event.StartTimeAssignment,
@@ -121,7 +122,7 @@ func Test_lapsTimeReporter(t *testing.T) {
 
 		completedLaps := 2
 
-		lt := newLapsTimeReporter(lapsCount, lapLen)
+		lt := newLapsTimeReporter(slices.Repeat([]uint32{lapLen}, int(lapsCount)))
 
 		lt.NotifyWithEvent(event.Event{
 			ID:    event.StartTimeAssignment,
@@ -148,4 +149,99 @@ func Test_lapsTimeReporter(t *testing.T) {
 
 		assert.Equal(t, expected, got)
 	})
+
+	t.Run("with lap ended before scheduled start", func(t *testing.T) {
+		t.Parallel()
+
+		lt := newLapsTimeReporter(slices.Repeat([]uint32{lapLen}, int(lapsCount)))
+
+		lt.NotifyWithEvent(event.Event{
+			ID:    event.StartTimeAssignment,
+			Extra: lapEnds[0].Format(event.TimeFormat),
+		})
+		lt.NotifyWithEvent(event.Event{
+			Time: startTime,
+			ID:   event.CompetitorEndedMainLap,
+		})
+
+		assert.Equal(t, make([]mainLapInfo, len(intervals)), lt.GetLapTimesAndSpeed())
+		assert.Equal(t, uint32(1), lt.lapsCompleted)
+	})
+
+	t.Run("with cannot continue before start", func(t *testing.T) {
+		t.Parallel()
+
+		lt := newLapsTimeReporter(slices.Repeat([]uint32{lapLen}, int(lapsCount)))
+
+		lt.NotifyWithEvent(event.Event{
+			ID:    event.StartTimeAssignment,
+			Extra: startTime.Format(event.TimeFormat),
+		})
+		lt.NotifyWithEvent(event.Event{
+			Time: startTime,
+			ID:   event.CompetitorCannotContinue,
+		})
+		lt.NotifyWithEvent(event.Event{
+			Time: startTime,
+			ID:   event.CompetitorStarted,
+		})
+		lt.NotifyWithEvent(event.Event{
+			Time: lapEnds[0],
+			ID:   event.CompetitorEndedMainLap,
+		})
+
+		assert.Equal(t, uint32(1), lt.lapsCompleted)
+	})
+}
+
+func Test_lapsTimeReporter_withDifferentLapLens(t *testing.T) {
+	lapLens := []uint32{3000, 2500}
+	intervals := []time.Duration{time.Minute * 10, time.Minute * 8}
+
+	startTime := time.Date(0, time.January, 1, 10, 30, 0, 0, time.UTC)
+
+	lt := newLapsTimeReporter(lapLens)
+
+	lt.NotifyWithEvent(event.Event{
+		ID:    event.StartTimeAssignment,
+		Extra: startTime.Format(event.TimeFormat),
+	})
+	lt.NotifyWithEvent(event.Event{
+		Time: startTime.Add(intervals[0]),
+		ID:   event.CompetitorEndedMainLap,
+	})
+	lt.NotifyWithEvent(event.Event{
+		Time: startTime.Add(intervals[0] + intervals[1]),
+		ID:   event.CompetitorEndedMainLap,
+	})
+
+	assert.Equal(t,
+		[]mainLapInfo{
+			{Interval: intervals[0], Speed: 5},
+			{Interval: intervals[1], Speed: float64(2500) / intervals[1].Seconds()},
+		},
+		lt.GetLapTimesAndSpeed())
+}
+
+func Test_lapsTimeReporter_GetProjectedTime(t *testing.T) {
+	startTime := time.Date(0, time.January, 1, 10, 30, 0, 0, time.UTC)
+
+	lt := newLapsTimeReporter([]uint32{3000, 1500, 1500})
+
+	lt.NotifyWithEvent(event.Event{
+		ID:    event.StartTimeAssignment,
+		Extra: startTime.Format(event.TimeFormat),
+	})
+
+	_, ok := lt.GetProjectedTime()
+	assert.False(t, ok)
+
+	lt.NotifyWithEvent(event.Event{
+		Time: startTime.Add(time.Minute * 10),
+		ID:   event.CompetitorEndedMainLap,
+	})
+
+	projected, ok := lt.GetProjectedTime()
+	assert.True(t, ok)
+	assert.Equal(t, time.Minute*20, projected)
 }
@@ -0,0 +1,135 @@
+package report
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// OverallRankingTitle is the title of ranking with all competitors.
+const OverallRankingTitle = "Overall"
+
+// Ranking is a sorted Report with a title, in which every finished competitor
+// has a place and a gap to the leader.
+type Ranking struct {
+	Title  string
+	Report Report
+}
+
+// String formats Ranking and returns it as string.
+// The first line is a title, each next line is related to unique competitor.
+// Examples of formatted lines:
+//
+//	Overall
+//	1 +00:00:00.000 [00:44:51.123] 1 [{00:22:20.100, 2.2}, {00:22:31.023, 1.9}] {00:01:25.467, 0.496} 8/10
+//	2 +00:01:02.300 [00:45:53.423] 4 [{00:22:50.100, 2.1}, {00:23:03.323, 1.8}] {00:01:10.467, 0.501} 9/10
+//	- - [NotFinished] 3 [{00:29:03.872, 2.093}, {,}] {00:01:44.296, 0.481} 4/10
+func (ranking Ranking) String() string {
+	builder := strings.Builder{}
+
+	_, _ = builder.WriteString(ranking.Title)
+	_, _ = builder.WriteString("\n")
+
+	places := ranking.places()
+
+	for i, record := range ranking.Report {
+		placeValue, gapValue := "-", "-"
+
+		if places[i] != 0 {
+			placeValue = fmt.Sprint(places[i])
+			gapValue = "+" + formatDuration(record.totalTime-ranking.Report[0].totalTime)
+		}
+
+		_, _ = fmt.Fprintf(&builder, "%s %s %s\n", placeValue, gapValue, record)
+	}
+
+	return builder.String()
+}
+
+// places returns place of each record in Ranking. Competitors with equal total time
+// share the same place. Not finished competitors have zero place.
+func (ranking Ranking) places() []int {
+	return sharedPlaces(len(ranking.Report),
+		func(i int) bool {
+			return ranking.Report[i].finalState == StatusFinished
+		},
+		func(i int) bool {
+			return ranking.Report[i-1].totalTime == ranking.Report[i].totalTime
+		})
+}
+
+// sharedPlaces returns places of n sorted results. Results, for which ranked returns false,
+// have zero place. Result, which is tied with the previous ranked result, shares its place.
+func sharedPlaces(n int, ranked func(i int) bool, tied func(i int) bool) []int {
+	places := make([]int, n)
+
+	for i := range n {
+		if !ranked(i) {
+			continue
+		}
+
+		places[i] = i + 1
+		if i > 0 && places[i-1] != 0 && tied(i) {
+			places[i] = places[i-1]
+		}
+	}
+
+	return places
+}
+
+// Rankings is a list of Ranking.
+type Rankings []Ranking
+
+// String formats Rankings and returns it as string. Rankings are separated with empty line.
+func (rankings Rankings) String() string {
+	formatted := make([]string, 0, len(rankings))
+	for _, ranking := range rankings {
+		formatted = append(formatted, ranking.String())
+	}
+
+	return strings.Join(formatted, "\n")
+}
+
+// Rankings creates overall Ranking and a separate Ranking for each of given categories.
+// Records in all rankings are sorted with Report.Sort rules, but finished competitors
+// are placed first, so the leader is on the top of the ranking.
+func (report Report) Rankings(categories []string) Rankings {
+	rankings := make(Rankings, 0, len(categories)+1)
+
+	rankings = append(rankings, newRanking(OverallRankingTitle, report))
+
+	for _, category := range categories {
+		categoryReport := make(Report, 0)
+		for _, record := range report {
+			if record.category == category {
+				categoryReport = append(categoryReport, record)
+			}
+		}
+
+		rankings = append(rankings, newRanking(category, categoryReport))
+	}
+
+	return rankings
+}
+
+func newRanking(title string, report Report) Ranking {
+	sorted := slices.Clone(report)
+	sorted.Sort()
+
+	slices.SortStableFunc(sorted, func(first, second reportRecord) int {
+		if first.finalState == second.finalState || (first.finalState != StatusFinished && second.finalState != StatusFinished) {
+			return 0
+		}
+
+		if first.finalState == StatusFinished {
+			return -1
+		}
+
+		return 1
+	})
+
+	return Ranking{
+		Title:  title,
+		Report: sorted,
+	}
+}
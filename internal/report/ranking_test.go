@@ -0,0 +1,92 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Report_Rankings(t *testing.T) {
+	givenReport := Report([]reportRecord{
+		{
+			finalState:   StatusDNS,
+			competitorID: "1",
+			category:     "women",
+		},
+		{
+			totalTime:    time.Minute*30 + time.Millisecond*500,
+			finalState:   StatusFinished,
+			competitorID: "2",
+			category:     "men",
+		},
+		{
+			totalTime:    time.Minute * 29,
+			finalState:   StatusFinished,
+			competitorID: "3",
+			category:     "women",
+		},
+		{
+			totalTime:    time.Minute*31 + time.Second*2,
+			finalState:   StatusFinished,
+			competitorID: "4",
+			category:     "women",
+		},
+		{
+			finalState:   StatusDNF,
+			competitorID: "5",
+			category:     "men",
+		},
+		{
+			totalTime:    time.Minute * 29,
+			finalState:   StatusFinished,
+			competitorID: "6",
+		},
+	})
+
+	t.Run("without categories", func(t *testing.T) {
+		rankings := givenReport.Rankings(nil)
+
+		assert.Len(t, rankings, 1)
+		assert.Equal(t, OverallRankingTitle, rankings[0].Title)
+		assert.Equal(t,
+			strings.Join([]string{
+				"Overall",
+				"1 +00:00:00.000 [00:29:00.000] 3 [] {00:00:00.000, 0.000} 0/0",
+				"1 +00:00:00.000 [00:29:00.000] 6 [] {00:00:00.000, 0.000} 0/0",
+				"3 +00:01:00.500 [00:30:00.500] 2 [] {00:00:00.000, 0.000} 0/0",
+				"4 +00:02:02.000 [00:31:02.000] 4 [] {00:00:00.000, 0.000} 0/0",
+				"- - [NotStarted] 1 [] {00:00:00.000, 0.000} 0/0",
+				"- - [NotFinished] 5 [] {00:00:00.000, 0.000} 0/0",
+			}, "\n")+"\n",
+			rankings[0].String())
+	})
+
+	t.Run("with categories", func(t *testing.T) {
+		rankings := givenReport.Rankings([]string{"men", "women", "youth"})
+
+		assert.Len(t, rankings, 4)
+		assert.Equal(t,
+			strings.Join([]string{
+				"men",
+				"1 +00:00:00.000 [00:30:00.500] 2 [] {00:00:00.000, 0.000} 0/0",
+				"- - [NotFinished] 5 [] {00:00:00.000, 0.000} 0/0",
+				"",
+				"women",
+				"1 +00:00:00.000 [00:29:00.000] 3 [] {00:00:00.000, 0.000} 0/0",
+				"2 +00:02:02.000 [00:31:02.000] 4 [] {00:00:00.000, 0.000} 0/0",
+				"- - [NotStarted] 1 [] {00:00:00.000, 0.000} 0/0",
+				"",
+				"youth",
+			}, "\n")+"\n",
+			rankings[1:].String())
+	})
+
+	t.Run("report is not changed", func(t *testing.T) {
+		_ = givenReport.Rankings([]string{"men"})
+
+		assert.Equal(t, "1", givenReport[0].competitorID)
+		assert.Equal(t, "6", givenReport[5].competitorID)
+	})
+}
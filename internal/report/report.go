@@ -2,6 +2,7 @@
 package report
 
 import (
+	"cmp"
 	"fmt"
 	"slices"
 	"strings"
@@ -20,6 +21,9 @@ type Report []reportRecord
 //	[00:44:51.123] 1 [{00:22:20.100, 2.2}, {00:22:31.023, 1.9}] {00:01:25.467, 0.496} 8/10
 //	[NotStarted] 2 [{,}, {,}] {00:00:00.000, 0.000} 0/10
 //	[NotFinished] 3 [{00:29:03.872, 2.093}, {,}] {00:01:44.296, 0.481} 4/10
+//	[LAP] 5 [{00:22:20.100, 2.2}, {,}] {00:00:00.000, 0.000} 5/10
+//	[DSQ] 4 [{00:22:20.100, 2.2}, {,}] {00:00:00.000, 0.000} 2/10 (missed 3 penalty laps)
+//	[Running] 6 [{00:22:20.100, 2.2}, {,}] {00:00:00.000, 0.000} 5/10 (on lap 2, ended main lap at 10:23:50.100, projected 00:44:40.200)
 func (report Report) String() string {
 	builder := strings.Builder{}
 
@@ -31,36 +35,31 @@ func (report Report) String() string {
 	return builder.String()
 }
 
-// Sort Report records by competitors' total time.
+// Sort Report records by competitors' final status and total time.
 // Sorted records have the following order:
 //   - [NotStarted] competitors sorted by competitorID.
 //   - competirors sorted by total time.
+//   - [Running] competitors sorted by amount of completed laps in descending order, then by competitorID.
+//   - [LAP] competitors sorted by amount of completed laps in descending order, then by competitorID.
 //   - [NotFinished] competitors sorted by competitorID.
+//   - [DSQ] competitors sorted by competitorID.
 func (report Report) Sort() {
 	slices.SortFunc(report, func(first, second reportRecord) int {
 		if first.finalState != second.finalState {
-			if first.finalState == notStarted {
-				return -1
-			}
-
-			if second.finalState == notStarted {
-				return 1
-			}
+			return compareStatuses(first.finalState, second.finalState)
+		}
 
-			if first.finalState == notFinished {
-				return 1
-			}
+		res := 0
 
-			if second.finalState == notFinished {
-				return -1
-			}
+		switch first.finalState {
+		case StatusFinished:
+			res = cmp.Compare(first.totalTime, second.totalTime)
+		case StatusRunning, StatusLAP:
+			res = cmp.Compare(second.lapsCompleted(), first.lapsCompleted())
 		}
 
-		if first.finalState == second.finalState && first.finalState == finished {
-			res := int(first.totalTime - second.totalTime)
-			if res != 0 {
-				return res
-			}
+		if res != 0 {
+			return res
 		}
 
 		return strings.Compare(first.competitorID, second.competitorID)
@@ -69,15 +68,25 @@ func (report Report) Sort() {
 
 type reportRecord struct {
 	totalTime    time.Duration
-	finalState   totalTimeReporterCompetitorState
+	finalState   Status
 	competitorID string
+	category     string
+	team         string
 	mainLapsInfo []mainLapInfo
 	shootingInfo shootingInfo
+	corrections  []event.Event
+	// disqualification is set only for disqualified competitor.
+	disqualification event.Disqualification
+	// checkpoint is set only for competitor still on course in provisional report.
+	checkpoint checkpoint
+	// projectedTime is set only for competitor still on course in provisional report,
+	// if at least one main lap is completed.
+	projectedTime time.Duration
 }
 
 func (rr reportRecord) String() string {
 	totalTimeValue := string(rr.finalState)
-	if rr.finalState == finished {
+	if rr.finalState == StatusFinished {
 		totalTimeValue = formatDuration(rr.totalTime)
 	}
 
@@ -86,12 +95,39 @@ func (rr reportRecord) String() string {
 		mainLapInfoStrings = append(mainLapInfoStrings, info.String())
 	}
 
-	return fmt.Sprintf("[%s] %s [%s] %s",
+	line := fmt.Sprintf("[%s] %s [%s] %s",
 		totalTimeValue,
 		rr.competitorID,
 		strings.Join(mainLapInfoStrings, ", "),
 		rr.shootingInfo,
 	)
+
+	if rr.finalState == StatusDSQ && rr.disqualification.Text != "" {
+		line += fmt.Sprintf(" (%s)", rr.disqualification.Text)
+	}
+
+	if rr.finalState == StatusRunning && rr.checkpoint.ID != 0 {
+		progress := []string{rr.checkpoint.Location(), rr.checkpoint.String()}
+		if rr.projectedTime != 0 {
+			progress = append(progress, "projected "+formatDuration(rr.projectedTime))
+		}
+
+		line += fmt.Sprintf(" (%s)", strings.Join(progress, ", "))
+	}
+
+	return line
+}
+
+// lapsCompleted returns amount of completed main laps.
+func (rr reportRecord) lapsCompleted() int {
+	completed := 0
+	for _, info := range rr.mainLapsInfo {
+		if info.Interval != 0 {
+			completed += 1
+		}
+	}
+
+	return completed
 }
 
 func formatDuration(d time.Duration) string {
@@ -12,7 +12,7 @@ func Test_Report_String(t *testing.T) {
 	givenReport := Report([]reportRecord{
 		{
 			totalTime:    time.Minute*1 + time.Second*2 + time.Millisecond*345,
-			finalState:   finished,
+			finalState:   StatusFinished,
 			competitorID: "1",
 			mainLapsInfo: []mainLapInfo{
 				{
@@ -32,7 +32,7 @@ func Test_Report_String(t *testing.T) {
 			},
 		},
 		{
-			finalState:   notStarted,
+			finalState:   StatusDNS,
 			competitorID: "2",
 			mainLapsInfo: make([]mainLapInfo, 2),
 			shootingInfo: shootingInfo{
@@ -40,7 +40,7 @@ func Test_Report_String(t *testing.T) {
 			},
 		},
 		{
-			finalState:   notFinished,
+			finalState:   StatusDNF,
 			competitorID: "3",
 			mainLapsInfo: []mainLapInfo{
 				{
@@ -66,3 +66,27 @@ func Test_Report_String(t *testing.T) {
 
 	assert.Equal(t, expectedString, givenReport.String())
 }
+
+func Test_Report_Sort(t *testing.T) {
+	lap := mainLapInfo{Interval: time.Minute * 10, Speed: 5}
+
+	givenReport := Report([]reportRecord{
+		{finalState: StatusDSQ, competitorID: "1"},
+		{finalState: StatusDNF, competitorID: "2"},
+		{finalState: StatusLAP, competitorID: "3", mainLapsInfo: []mainLapInfo{lap, {}, {}}},
+		{finalState: StatusFinished, competitorID: "4", totalTime: time.Minute * 31},
+		{finalState: StatusDNS, competitorID: "5"},
+		{finalState: StatusLAP, competitorID: "6", mainLapsInfo: []mainLapInfo{lap, lap, {}}},
+		{finalState: StatusFinished, competitorID: "7", totalTime: time.Minute * 30},
+		{finalState: StatusDSQ, competitorID: "0"},
+	})
+
+	givenReport.Sort()
+
+	competitorIDs := make([]string, 0, len(givenReport))
+	for _, record := range givenReport {
+		competitorIDs = append(competitorIDs, record.competitorID)
+	}
+
+	assert.Equal(t, []string{"5", "7", "4", "6", "3", "2", "0", "1"}, competitorIDs)
+}
@@ -1,6 +1,9 @@
 package report
 
 import (
+	"maps"
+	"slices"
+
 	"github.com/AleksandrMatsko/yadro-biathlon/internal/config"
 	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
 )
@@ -35,12 +38,27 @@ func (r *Reporter) NotifyWithEvent(incomingEvent event.Event) {
 	reporter.NotifyWithEvent(incomingEvent)
 }
 
-// MakeReport creates Report from previously observed events.
+// MakeReport creates Report from previously observed events. Records are ordered by competitorID.
+// Competitors still on course are considered not finished. State of Reporter is not changed,
+// so MakeReport can be called repeatedly, while events are observed.
 func (r *Reporter) MakeReport() Report {
+	return r.makeReport(false)
+}
+
+// MakeProvisionalReport creates Report of the current moment of the race from previously observed events.
+// Unlike MakeReport, competitors still on course have StatusRunning, their location, the last checkpoint
+// and projected total time. State of Reporter is not changed, so events can be observed after it.
+func (r *Reporter) MakeProvisionalReport() Report {
+	return r.makeReport(true)
+}
+
+func (r *Reporter) makeReport(provisional bool) Report {
 	records := make([]reportRecord, 0, len(r.reporters))
-	for competitorID, reporter := range r.reporters {
-		record := reporter.createRecord()
+	for _, competitorID := range slices.Sorted(maps.Keys(r.reporters)) {
+		record := r.reporters[competitorID].createRecord(provisional)
 		record.competitorID = competitorID
+		record.category = r.conf.Competitors[competitorID].Category
+		record.team = r.conf.Competitors[competitorID].Team
 
 		records = append(records, record)
 	}
@@ -49,16 +67,18 @@ func (r *Reporter) MakeReport() Report {
 }
 
 type competitorReporter struct {
-	totalTime *totalTimeReporter
-	lapsTime  *lapsTimeReporter
-	shooting  *shootingReporter
+	totalTime  *totalTimeReporter
+	lapsTime   *lapsTimeReporter
+	shooting   *shootingReporter
+	checkpoint *checkpointReporter
 }
 
 func newCompetitorReporter(conf config.BiathlonCompetition) *competitorReporter {
 	return &competitorReporter{
-		totalTime: newTotalTimeReporter(),
-		lapsTime:  newLapsTimeReporter(conf.Laps, conf.LapLen),
-		shooting:  newShootingReporter(conf.FiringLines, conf.PenaltyLen),
+		totalTime:  newTotalTimeReporter(),
+		lapsTime:   newLapsTimeReporter(conf.LapLengths()),
+		shooting:   newShootingReporter(conf.TargetsPerFiringLine(), conf.PenaltyLen, conf.FiringLineLaps, conf.FiringLinePositions),
+		checkpoint: newCheckpointReporter(),
 	}
 }
 
@@ -66,14 +86,26 @@ func (cr *competitorReporter) NotifyWithEvent(e event.Event) {
 	cr.totalTime.NotifyWithEvent(e)
 	cr.lapsTime.NotifyWithEvent(e)
 	cr.shooting.NotifyWithEvent(e)
+	cr.checkpoint.NotifyWithEvent(e)
 }
 
-func (cr *competitorReporter) createRecord() reportRecord {
+func (cr *competitorReporter) createRecord(provisional bool) reportRecord {
 	record := reportRecord{}
 
 	record.totalTime, record.finalState = cr.totalTime.GetTotalTime()
 	record.mainLapsInfo = cr.lapsTime.GetLapTimesAndSpeed()
 	record.shootingInfo = cr.shooting.GetInfo()
+	record.corrections = slices.Clone(cr.totalTime.corrections)
+	record.disqualification, _ = cr.totalTime.Disqualification()
+
+	if provisional && record.finalState == StatusDNF && cr.totalTime.Running() {
+		record.finalState = StatusRunning
+		record.checkpoint, _ = cr.checkpoint.GetCheckpoint()
+
+		if projected, ok := cr.lapsTime.GetProjectedTime(); ok {
+			record.projectedTime = max(projected+cr.totalTime.adjustment, 0)
+		}
+	}
 
 	return record
 }
@@ -0,0 +1,256 @@
+package report
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/competition"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/config"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event/parser"
+	"github.com/stretchr/testify/assert"
+)
+
+// givenRaceEvents returns incoming and outgoing events of the race with 2 laps and 1 firing line.
+func givenRaceEvents(t *testing.T) []event.Event {
+	lines := []struct {
+		time         string
+		id           event.EventID
+		competitorID string
+		extra        string
+	}{
+		{"09:30:00.000", event.CompetitorRegistration, "1", ""},
+		{"09:30:01.000", event.CompetitorRegistration, "2", ""},
+		{"09:30:02.000", event.CompetitorRegistration, "3", ""},
+		{"09:40:00.000", event.StartTimeAssignment, "1", "10:00:00.000"},
+		{"09:40:01.000", event.StartTimeAssignment, "2", "10:01:00.000"},
+		{"09:40:02.000", event.StartTimeAssignment, "3", "10:02:00.000"},
+		{"10:00:01.000", event.CompetitorStarted, "1", ""},
+		{"10:01:01.000", event.CompetitorStarted, "2", ""},
+		{"10:02:01.000", event.CompetitorStarted, "3", ""},
+		{"10:10:00.000", event.CompetitorOnFiringRange, "1", "1"},
+		{"10:10:05.000", event.ShotFired, "1", "1 hit"},
+		{"10:10:06.000", event.TargetHit, "1", "1"},
+		{"10:10:08.000", event.ShotFired, "1", "2 miss"},
+		{"10:10:20.000", event.CompetitorLeftFiringRange, "1", ""},
+		{"10:10:30.000", event.CompetitorEnterPenaltyLaps, "1", ""},
+		{"10:12:00.000", event.CompetitorLeftPenaltyLaps, "1", ""},
+		{"10:15:00.000", event.CompetitorEndedMainLap, "1", ""},
+		{"10:16:00.000", event.CompetitorEndedMainLap, "2", ""},
+		{"10:17:00.000", event.CompetitorCannotContinue, "3", "Lost in the forest"},
+		{"10:20:00.000", event.JuryTimePenalty, "2", "00:00:30 false start"},
+		{"10:29:00.000", event.CompetitorEndedMainLap, "1", ""},
+		{"10:29:00.000", event.CompetitorFinished, "1", ""},
+		{"10:31:00.000", event.CompetitorEndedMainLap, "2", ""},
+		{"10:31:00.000", event.CompetitorFinished, "2", ""},
+	}
+
+	events := make([]event.Event, 0, len(lines))
+	for _, line := range lines {
+		eventTime, err := parser.ParseTime(line.time)
+		assert.Nil(t, err)
+
+		events = append(events, event.Event{Time: eventTime, ID: line.id, CompetitorID: line.competitorID, Extra: line.extra})
+	}
+
+	return events
+}
+
+func givenRaceConfig() config.BiathlonCompetition {
+	return config.BiathlonCompetition{
+		Laps:        2,
+		LapLen:      3000,
+		PenaltyLen:  150,
+		FiringLines: 1,
+		Targets:     2,
+		Competitors: map[string]config.Competitor{"1": {Team: "RUS"}, "2": {Team: "RUS"}},
+		Teams:       &config.TeamRanking{BestN: 2},
+	}
+}
+
+func Test_Reporter_MakeReport(t *testing.T) {
+	events := givenRaceEvents(t)
+	conf := givenRaceConfig()
+
+	untouched := NewReporter(conf)
+	for _, e := range events {
+		untouched.NotifyWithEvent(e)
+	}
+
+	expected := untouched.MakeReport()
+
+	t.Run("is idempotent", func(t *testing.T) {
+		for range 3 {
+			assert.Equal(t, expected, untouched.MakeReport())
+		}
+	})
+
+	t.Run("does not affect observing events", func(t *testing.T) {
+		reporter := NewReporter(conf)
+
+		for _, e := range events {
+			first := reporter.MakeReport()
+			provisional := reporter.MakeProvisionalReport()
+
+			assert.Equal(t, first, reporter.MakeReport())
+			assert.Equal(t, provisional, reporter.MakeProvisionalReport())
+
+			reporter.NotifyWithEvent(e)
+		}
+
+		assert.Equal(t, expected, reporter.MakeReport())
+	})
+
+	t.Run("is not changed by changes of returned report", func(t *testing.T) {
+		before := bytes.Buffer{}
+		assert.Nil(t, NewDocument(untouched.MakeReport(), conf).Write(&before, FormatJSON))
+
+		made := untouched.MakeReport()
+		made.Sort()
+
+		for i := range made {
+			made[i].corrections = append(made[i].corrections[:0], event.Event{ID: event.JuryTimeBonus})
+			made[i].mainLapsInfo[0] = mainLapInfo{}
+		}
+
+		assert.Nil(t, NewDocument(made, conf).Write(io.Discard, FormatJSON))
+
+		after := bytes.Buffer{}
+		assert.Nil(t, NewDocument(untouched.MakeReport(), conf).Write(&after, FormatJSON))
+
+		assert.Equal(t, before.String(), after.String())
+	})
+}
+
+func Test_Reporter_MakeProvisionalReport(t *testing.T) {
+	reporter := NewReporter(config.BiathlonCompetition{Laps: 2, LapLen: 3000, FiringLines: 1})
+
+	startTime := time.Date(0, time.January, 1, 10, 0, 0, 0, time.UTC)
+
+	for _, competitorID := range []string{"1", "2", "3"} {
+		reporter.NotifyWithEvent(event.Event{ID: event.CompetitorRegistration, CompetitorID: competitorID})
+		reporter.NotifyWithEvent(event.Event{ID: event.StartTimeAssignment, CompetitorID: competitorID, Extra: "10:00:00.000"})
+	}
+
+	for _, competitorID := range []string{"1", "2"} {
+		reporter.NotifyWithEvent(event.Event{Time: startTime, ID: event.CompetitorStarted, CompetitorID: competitorID})
+	}
+
+	reporter.NotifyWithEvent(event.Event{Time: startTime.Add(time.Minute * 10), ID: event.CompetitorEndedMainLap, CompetitorID: "2"})
+	reporter.NotifyWithEvent(event.Event{
+		Time:         startTime.Add(time.Minute * 11),
+		ID:           event.JuryTimePenalty,
+		CompetitorID: "2",
+		Extra:        "00:00:30",
+	})
+
+	expected := "[NotStarted] 3 [{,}, {,}] {00:00:00.000, 0.000} 0/5\n" +
+		"[Running] 2 [{00:10:00.000, 5.000}, {,}] {00:00:00.000, 0.000} 0/5 (on lap 2, ended main lap at 10:10:00.000, projected 00:20:30.000)\n" +
+		"[Running] 1 [{,}, {,}] {00:00:00.000, 0.000} 0/5 (on lap 1, started at 10:00:00.000)\n"
+
+	for range 2 {
+		provisional := reporter.MakeProvisionalReport()
+		provisional.Sort()
+
+		assert.Equal(t, expected, provisional.String())
+	}
+
+	final := reporter.MakeReport()
+	final.Sort()
+
+	assert.Equal(t,
+		"[NotStarted] 3 [{,}, {,}] {00:00:00.000, 0.000} 0/5\n"+
+			"[NotFinished] 1 [{,}, {,}] {00:00:00.000, 0.000} 0/5\n"+
+			"[NotFinished] 2 [{00:10:00.000, 5.000}, {,}] {00:00:00.000, 0.000} 0/5\n",
+		final.String())
+}
+
+// Fuzz_Pipeline handles fuzzed events file by competition.Biathlon and Reporter and checks invariants
+// of report. Lines, that are not parsed or not checked by parser.TargetsChecker, are skipped as the report
+// command rejects them. Events must occur sequentially in time, so earlier events are skipped too.
+func Fuzz_Pipeline(f *testing.F) {
+	for _, path := range []string{
+		"../../testdata/events",
+		"../../testdata/from_task_readme/events",
+		"../../task/sunny_5_skiers/events",
+	} {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			f.Fatal(err)
+		}
+
+		f.Add(string(data), false)
+		f.Add(string(data), true)
+	}
+
+	f.Add(strings.Join([]string{
+		"[09:00:00.000] 1 1",
+		"[09:00:00.000] 2 1 10:00:00.000",
+		"[10:00:00.000] 4 1",
+		"[10:20:00.000] 10 1",
+		"[10:40:00.000] 10 1",
+		"[10:45:00.000] 13 1 00:00:10.000 Missed penalty loop",
+	}, "\n"), false)
+
+	f.Fuzz(func(t *testing.T, events string, pullLapped bool) {
+		conf := config.BiathlonCompetition{
+			Laps:        2,
+			LapLen:      3500,
+			PenaltyLen:  150,
+			FiringLines: 2,
+			PullLapped:  pullLapped,
+			Start:       "10:00:00.000",
+			StartDelta:  "00:01:30",
+		}
+
+		reporter := NewReporter(conf)
+		targetsChecker := parser.NewTargetsChecker(conf)
+
+		biathlon, err := competition.NewBiathlon(conf, reporter)
+		assert.Nil(t, err)
+
+		handled := 0
+
+		var last time.Time
+
+		for _, line := range strings.Split(events, "\n") {
+			e, parseErr := parser.ParseSingleLine(line)
+			if parseErr != nil || (handled > 0 && e.Time.Before(last)) || targetsChecker.Check(e) != nil {
+				continue
+			}
+
+			last = e.Time
+			handled += 1
+			biathlon.HandleEvent(e)
+		}
+
+		assert.Nil(t, biathlon.Err())
+
+		for _, res := range []Report{reporter.MakeReport(), reporter.MakeProvisionalReport()} {
+			for _, record := range res {
+				assert.GreaterOrEqual(t, record.totalTime, time.Duration(0), "negative total time: %s", record)
+				assert.GreaterOrEqual(t, record.projectedTime, time.Duration(0), "negative projected time: %s", record)
+				assert.GreaterOrEqual(t, record.shootingInfo.TimeSpentOnPenaltyLaps, time.Duration(0), "negative penalty time: %s", record)
+				assert.LessOrEqual(t, record.shootingInfo.TotalHitTargets, record.shootingInfo.TotalTargets, "more hits than shots: %s", record)
+
+				for _, info := range record.mainLapsInfo {
+					assert.GreaterOrEqual(t, info.Interval, time.Duration(0), "negative lap time: %s", record)
+				}
+
+				for _, stage := range record.shootingInfo.Stages {
+					assert.LessOrEqual(t, len(stage.MissedShots), int(stage.Shots), "more misses than shots: %s", record)
+				}
+
+				// laps of zero duration are not shown in report, so completed laps are taken from reporter.
+				if record.finalState == StatusFinished {
+					competitor := reporter.reporters[record.competitorID]
+					assert.Equal(t, conf.Laps, competitor.lapsTime.lapsCompleted, "finished without all laps: %s", record)
+				}
+			}
+		}
+	})
+}
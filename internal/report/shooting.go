@@ -2,9 +2,13 @@ package report
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/config"
 	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event/parser"
 )
 
 type shootingReporterCompetitorState uint8
@@ -19,14 +23,22 @@ const (
 // shootingReporter is responsible for calculating several values:
 //   - total number of hit targets;
 //   - total time spent on penalty laps;
-//   - average speed on penalty laps.
+//   - average speed on penalty laps;
+//   - accuracy and range time for each shooting position.
 //
 // Penalty laps calculations is here because, penalty laps count depends on
 // the amount of not hit targets on the firing range.
+//
+// Shootings of disqualified competitor are still counted, as the jury can reinstate the competitor.
+//
+// Visits of firing lines, that are not allowed by parser.FiringLines, are ignored.
+// Targets and position of the visited firing line are taken by its index from parser.FiringLines.
 type shootingReporter struct {
-	hitTargetsOnCurrentFireRange map[string]struct{}
-	firingLinesCount             uint32
-	completedShootings           uint32
+	hitTargetsOnCurrentFireRange map[uint32]struct{}
+	targetsOnCurrentFireRange    uint32
+	targetsPerFiringLine         []uint32
+	firingLines                  *parser.FiringLines
+	positions                    []string
 	totalNumberOfHitTarges       uint32
 
 	totalPenaltyLapCount             uint32
@@ -36,14 +48,20 @@ type shootingReporter struct {
 	timeSpentOnPenaltyLaps time.Duration
 	enterPenaltyLap        time.Time
 
+	stages []shootingStage
+
 	state shootingReporterCompetitorState
 }
 
-func newShootingReporter(firingLinesCount uint32, penaltyLapLen uint32) *shootingReporter {
+// newShootingReporter creates shootingReporter. targetsPerFiringLine is the number of targets
+// on each firing line. firingLineLaps is optional number of lap, which ends with firing line,
+// for each firing line. positions is optional shooting position on each firing line.
+func newShootingReporter(targetsPerFiringLine []uint32, penaltyLapLen uint32, firingLineLaps []uint32, positions []string) *shootingReporter {
 	return &shootingReporter{
-		hitTargetsOnCurrentFireRange: make(map[string]struct{}, len(event.AvailableTargets)),
-		firingLinesCount:             firingLinesCount,
-		completedShootings:           0,
+		hitTargetsOnCurrentFireRange: make(map[uint32]struct{}, config.DefaultTargets),
+		targetsPerFiringLine:         targetsPerFiringLine,
+		firingLines:                  parser.NewFiringLines(uint32(len(targetsPerFiringLine)), firingLineLaps),
+		positions:                    positions,
 		totalNumberOfHitTarges:       0,
 		totalPenaltyLapCount:         0,
 		penaltyLapLen:                penaltyLapLen,
@@ -56,11 +74,22 @@ func (s *shootingReporter) NotifyWithEvent(e event.Event) {
 		return
 	}
 
-	if e.ID == event.CompetitorCannotContinue || e.ID == event.CompetitorDisqualified || e.ID == event.CompetitorFinished {
+	if e.ID == event.CompetitorCannotContinue || e.ID == event.CompetitorLapped || e.ID == event.CompetitorFinished {
 		s.state = ended
 		return
 	}
 
+	// firing lines are counted in any state, so they are the same as in parser.TargetsChecker.
+	if e.ID == event.CompetitorOnFiringRange {
+		s.onFiringRange(e)
+		return
+	}
+
+	if e.ID == event.CompetitorEndedMainLap {
+		s.firingLines.EndLap()
+		return
+	}
+
 	switch s.state {
 	case runningMainLap:
 		s.onRunningMainLap(e)
@@ -71,14 +100,26 @@ func (s *shootingReporter) NotifyWithEvent(e event.Event) {
 	}
 }
 
-func (s *shootingReporter) onRunningMainLap(e event.Event) {
-	if e.ID == event.CompetitorOnFiringRange && s.completedShootings < s.firingLinesCount {
-		clear(s.hitTargetsOnCurrentFireRange)
-		s.state = shooting
-		s.penaltyLapToPerformAfterShooting = uint8(len(event.AvailableTargets))
+// onFiringRange starts shooting on the visited firing line, if the visit is allowed
+// and the competitor is running main lap.
+func (s *shootingReporter) onFiringRange(e event.Event) {
+	firingLine, err := s.firingLines.Visit(e.Extra)
+	if err != nil || s.state != runningMainLap {
 		return
 	}
 
+	clear(s.hitTargetsOnCurrentFireRange)
+	s.state = shooting
+	s.targetsOnCurrentFireRange = s.targetsPerFiringLine[firingLine]
+	s.penaltyLapToPerformAfterShooting = uint8(s.targetsOnCurrentFireRange)
+	s.stages = append(s.stages, shootingStage{
+		firingLine: firingLine,
+		targets:    s.targetsOnCurrentFireRange,
+		arrival:    e.Time,
+	})
+}
+
+func (s *shootingReporter) onRunningMainLap(e event.Event) {
 	if e.ID == event.CompetitorEnterPenaltyLaps {
 		s.enterPenaltyLap = e.Time
 		s.state = runningPenaltyLaps
@@ -88,22 +129,52 @@ func (s *shootingReporter) onRunningMainLap(e event.Event) {
 
 func (s *shootingReporter) onShooting(e event.Event) {
 	if e.ID == event.TargetHit {
-		if _, ok := s.hitTargetsOnCurrentFireRange[e.Extra]; !ok {
-			s.hitTargetsOnCurrentFireRange[e.Extra] = struct{}{}
-			s.totalNumberOfHitTarges += 1
-			s.penaltyLapToPerformAfterShooting -= 1
+		target, err := parser.ParseTarget(e.Extra)
+		if err == nil {
+			s.hitTarget(target)
+		}
+
+		return
+	}
+
+	if e.ID == event.ShotFired {
+		shot, err := parser.ParseShot(e.Extra)
+		if err != nil || shot.Number > s.targetsOnCurrentFireRange {
+			return
+		}
+
+		stage := &s.stages[len(s.stages)-1]
+		stage.shots = append(stage.shots, firedShot{time: e.Time, shot: shot})
+
+		if shot.Hit {
+			s.hitTarget(shot.Number)
 		}
 
 		return
 	}
 
 	if e.ID == event.CompetitorLeftFiringRange {
-		s.completedShootings += 1
+		s.stages[len(s.stages)-1].departure = e.Time
 		s.state = runningMainLap
 		return
 	}
 }
 
+// hitTarget counts hit of the target on the current firing line. Hit shot with the same number
+// as hit target is the same hit, so each target is counted once.
+func (s *shootingReporter) hitTarget(target uint32) {
+	if target > s.targetsOnCurrentFireRange {
+		return
+	}
+
+	if _, ok := s.hitTargetsOnCurrentFireRange[target]; !ok {
+		s.hitTargetsOnCurrentFireRange[target] = struct{}{}
+		s.totalNumberOfHitTarges += 1
+		s.penaltyLapToPerformAfterShooting -= 1
+		s.stages[len(s.stages)-1].hits += 1
+	}
+}
+
 func (s *shootingReporter) onRunningPenaltyLaps(e event.Event) {
 	if e.ID == event.CompetitorLeftPenaltyLaps {
 		s.totalPenaltyLapCount += uint32(s.penaltyLapToPerformAfterShooting)
@@ -120,6 +191,8 @@ type shootingInfo struct {
 	TotalHitTargets           uint32
 	TimeSpentOnPenaltyLaps    time.Duration
 	AverageSpeedOnPenaltyLaps float64
+	Stages                    []stageInfo
+	Positions                 []positionInfo
 }
 
 func (info shootingInfo) String() string {
@@ -134,12 +207,233 @@ func (info shootingInfo) String() string {
 func (s *shootingReporter) GetInfo() shootingInfo {
 	res := shootingInfo{}
 
-	res.TotalTargets = uint32(len(event.AvailableTargets) * int(s.firingLinesCount))
+	for _, targets := range s.targetsPerFiringLine {
+		res.TotalTargets += targets
+	}
 	res.TotalHitTargets = s.totalNumberOfHitTarges
 	res.TimeSpentOnPenaltyLaps = s.timeSpentOnPenaltyLaps
+	for _, stage := range s.stages {
+		res.Stages = append(res.Stages, stage.info())
+	}
+
+	res.Positions = s.positionsInfo()
+
 	if s.timeSpentOnPenaltyLaps != 0 {
 		res.AverageSpeedOnPenaltyLaps = float64(s.totalPenaltyLapCount*s.penaltyLapLen) / s.timeSpentOnPenaltyLaps.Seconds()
 	}
 
 	return res
 }
+
+// shootingStage contains results of the competitor on single firing line.
+type shootingStage struct {
+	// firingLine - index of firing line.
+	firingLine uint32
+	targets    uint32
+	hits       uint32
+	arrival    time.Time
+	// departure is zero if the competitor has not left firing line.
+	departure time.Time
+	shots     []firedShot
+}
+
+type firedShot struct {
+	time time.Time
+	shot parser.Shot
+}
+
+// stageInfo contains results of shot-level analysis of single shooting stage.
+type stageInfo struct {
+	// FirstShotTime - time interval between arrival on firing line and the first shot.
+	FirstShotTime time.Duration
+	// Rhythm - average time interval between consecutive shots.
+	Rhythm time.Duration
+	// Shots - amount of fired shots.
+	Shots uint32
+	// MissedShots - numbers of missed shots in ascending order.
+	MissedShots []uint32
+}
+
+func (info stageInfo) String() string {
+	if info.Shots == 0 {
+		return "{,}"
+	}
+
+	missed := make([]string, 0, len(info.MissedShots))
+	for _, number := range info.MissedShots {
+		missed = append(missed, fmt.Sprint(number))
+	}
+
+	return fmt.Sprintf("{%s, %s, %d/%d, [%s]}",
+		formatDuration(info.FirstShotTime),
+		formatDuration(info.Rhythm),
+		info.Shots-uint32(len(info.MissedShots)),
+		info.Shots,
+		strings.Join(missed, ", "),
+	)
+}
+
+func (stage shootingStage) info() stageInfo {
+	info := stageInfo{
+		Shots:       uint32(len(stage.shots)),
+		MissedShots: make([]uint32, 0),
+	}
+
+	if len(stage.shots) == 0 {
+		return info
+	}
+
+	info.FirstShotTime = stage.shots[0].time.Sub(stage.arrival)
+	if len(stage.shots) > 1 {
+		info.Rhythm = stage.shots[len(stage.shots)-1].time.Sub(stage.shots[0].time) / time.Duration(len(stage.shots)-1)
+	}
+
+	for _, fired := range stage.shots {
+		if !fired.shot.Hit {
+			info.MissedShots = append(info.MissedShots, fired.shot.Number)
+		}
+	}
+
+	slices.Sort(info.MissedShots)
+
+	return info
+}
+
+// ShootingStagesTitle is the title of text report section with shot-level analysis.
+const ShootingStagesTitle = "Shooting"
+
+// shootingStages formats shot-level analysis of each competitor, who fired at least one shot,
+// in the order of sorted Report. Returns empty string if there were no shot events.
+// Examples of formatted lines (first shot time, rhythm, hits/shots and missed shots):
+//
+//	1 [{00:00:25.100, 00:00:03.250, 3/5, [2, 5]}, {00:00:21.000, 00:00:02.900, 5/5, []}]
+//	3 [{00:00:30.000, 00:00:04.000, 4/5, [1]}, {,}]
+func (report Report) shootingStages() string {
+	sorted := slices.Clone(report)
+	sorted.Sort()
+
+	builder := strings.Builder{}
+
+	for _, record := range sorted {
+		if !record.shootingInfo.hasShots() {
+			continue
+		}
+
+		stageStrings := make([]string, 0, len(record.shootingInfo.Stages))
+		for _, stage := range record.shootingInfo.Stages {
+			stageStrings = append(stageStrings, stage.String())
+		}
+
+		_, _ = fmt.Fprintf(&builder, "%s [%s]\n", record.competitorID, strings.Join(stageStrings, ", "))
+	}
+
+	if builder.Len() == 0 {
+		return ""
+	}
+
+	return ShootingStagesTitle + "\n" + builder.String()
+}
+
+func (info shootingInfo) hasShots() bool {
+	for _, stage := range info.Stages {
+		if stage.Shots != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// positionInfo contains results of the competitor in single shooting position.
+type positionInfo struct {
+	Position string
+	// Targets - total number of targets on visited firing lines with this position.
+	Targets uint32
+	Hits    uint32
+	// RangeTime - total time spent on firing lines with this position.
+	RangeTime time.Duration
+}
+
+// Accuracy returns percentage of hit targets.
+func (info positionInfo) Accuracy() float64 {
+	if info.Targets == 0 {
+		return 0
+	}
+
+	return float64(info.Hits) * 100 / float64(info.Targets)
+}
+
+func (info positionInfo) String() string {
+	return fmt.Sprintf("{%s, %d/%d, %.1f%%, %s}",
+		info.Position,
+		info.Hits,
+		info.Targets,
+		info.Accuracy(),
+		formatDuration(info.RangeTime),
+	)
+}
+
+// positionsInfo aggregates completed shooting stages by position. Positions are returned
+// in order of their first appearance in config. Returns nil if positions are not configured.
+func (s *shootingReporter) positionsInfo() []positionInfo {
+	if len(s.positions) == 0 {
+		return nil
+	}
+
+	res := make([]positionInfo, 0, 2)
+	indexes := make(map[string]int, 2)
+
+	for _, position := range s.positions {
+		if _, ok := indexes[position]; !ok {
+			indexes[position] = len(res)
+			res = append(res, positionInfo{Position: position})
+		}
+	}
+
+	for _, stage := range s.stages {
+		if stage.departure.IsZero() || int(stage.firingLine) >= len(s.positions) {
+			continue
+		}
+
+		info := &res[indexes[s.positions[stage.firingLine]]]
+		info.Targets += stage.targets
+		info.Hits += stage.hits
+		info.RangeTime += stage.departure.Sub(stage.arrival)
+	}
+
+	return res
+}
+
+// PositionsTitle is the title of text report section with results by shooting position.
+const PositionsTitle = "Positions"
+
+// positions formats results of each competitor by shooting position in the order of sorted Report.
+// Returns empty string if positions are not configured.
+// Example of formatted line (position, hits/targets, accuracy and range time):
+//
+//	1 [{prone, 9/10, 90.0%, 00:01:02.500}, {standing, 7/10, 70.0%, 00:01:10.250}]
+func (report Report) positions() string {
+	sorted := slices.Clone(report)
+	sorted.Sort()
+
+	builder := strings.Builder{}
+
+	for _, record := range sorted {
+		if len(record.shootingInfo.Positions) == 0 {
+			continue
+		}
+
+		positionStrings := make([]string, 0, len(record.shootingInfo.Positions))
+		for _, position := range record.shootingInfo.Positions {
+			positionStrings = append(positionStrings, position.String())
+		}
+
+		_, _ = fmt.Fprintf(&builder, "%s [%s]\n", record.competitorID, strings.Join(positionStrings, ", "))
+	}
+
+	if builder.Len() == 0 {
+		return ""
+	}
+
+	return PositionsTitle + "\n" + builder.String()
+}
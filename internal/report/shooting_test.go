@@ -1,29 +1,36 @@
 package report
 
 import (
+	"fmt"
+	"slices"
 	"testing"
 	"time"
 
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/config"
 	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
 	"github.com/stretchr/testify/assert"
 )
 
+var availableTargets = []string{"1", "2", "3", "4", "5"}
+
 func Test_shootingReporter(t *testing.T) {
 	const (
 		firingLines   uint32 = 2
 		penaltyLapLen uint32 = 100
 	)
 
+	targetsPerFiringLine := slices.Repeat([]uint32{uint32(len(availableTargets))}, int(firingLines))
+
 	t.Run("when all targets are hit", func(t *testing.T) {
 		t.Parallel()
 
-		totalTargets := firingLines * uint32(len(event.AvailableTargets))
+		totalTargets := firingLines * uint32(len(availableTargets))
 
-		reporter := newShootingReporter(firingLines, penaltyLapLen)
+		reporter := newShootingReporter(targetsPerFiringLine, penaltyLapLen, nil, nil)
 
 		for range firingLines {
 			reporter.NotifyWithEvent(event.Event{ID: event.CompetitorOnFiringRange})
-			for target := range event.AvailableTargets {
+			for _, target := range availableTargets {
 				reporter.NotifyWithEvent(event.Event{
 					ID:    event.TargetHit,
 					Extra: target,
@@ -42,7 +49,7 @@ func Test_shootingReporter(t *testing.T) {
 	t.Run("when not all targets are hit", func(t *testing.T) {
 		t.Parallel()
 
-		totalTargets := firingLines * uint32(len(event.AvailableTargets))
+		totalTargets := firingLines * uint32(len(availableTargets))
 
 		intervals := []time.Duration{
 			time.Minute*2 + time.Second + time.Millisecond*535,
@@ -56,7 +63,7 @@ func Test_shootingReporter(t *testing.T) {
 		enterSecondPenalty := time.Date(0, time.January, 1, 11, 45, 0, 0, time.UTC)
 		leaveSecondPenalty := enterSecondPenalty.Add(intervals[1])
 
-		reporter := newShootingReporter(firingLines, penaltyLapLen)
+		reporter := newShootingReporter(targetsPerFiringLine, penaltyLapLen, nil, nil)
 
 		reporter.NotifyWithEvent(event.Event{ID: event.CompetitorOnFiringRange})
 		reporter.NotifyWithEvent(event.Event{ID: event.TargetHit, Extra: "1"})
@@ -100,9 +107,9 @@ func Test_shootingReporter(t *testing.T) {
 		t.Run("and was running main lap without penalty laps", func(t *testing.T) {
 			t.Parallel()
 
-			totalTargets := firingLines * uint32(len(event.AvailableTargets))
+			totalTargets := firingLines * uint32(len(availableTargets))
 
-			reporter := newShootingReporter(firingLines, penaltyLapLen)
+			reporter := newShootingReporter(targetsPerFiringLine, penaltyLapLen, nil, nil)
 
 			reporter.NotifyWithEvent(event.Event{ID: event.CompetitorOnFiringRange})
 			reporter.NotifyWithEvent(event.Event{ID: event.TargetHit, Extra: "1"})
@@ -126,7 +133,7 @@ func Test_shootingReporter(t *testing.T) {
 			reporter.NotifyWithEvent(event.Event{ID: event.CompetitorCannotContinue})
 
 			reporter.NotifyWithEvent(event.Event{ID: event.CompetitorOnFiringRange})
-			for target := range event.AvailableTargets {
+			for _, target := range availableTargets {
 				reporter.NotifyWithEvent(event.Event{
 					ID:    event.TargetHit,
 					Extra: target,
@@ -144,9 +151,9 @@ func Test_shootingReporter(t *testing.T) {
 		t.Run("and was shooting", func(t *testing.T) {
 			t.Parallel()
 
-			totalTargets := firingLines * uint32(len(event.AvailableTargets))
+			totalTargets := firingLines * uint32(len(availableTargets))
 
-			reporter := newShootingReporter(firingLines, penaltyLapLen)
+			reporter := newShootingReporter(targetsPerFiringLine, penaltyLapLen, nil, nil)
 
 			reporter.NotifyWithEvent(event.Event{ID: event.CompetitorOnFiringRange})
 			reporter.NotifyWithEvent(event.Event{ID: event.TargetHit, Extra: "1"})
@@ -187,9 +194,9 @@ func Test_shootingReporter(t *testing.T) {
 		t.Run("and was running main lap and have penalty laps to complete", func(t *testing.T) {
 			t.Parallel()
 
-			totalTargets := firingLines * uint32(len(event.AvailableTargets))
+			totalTargets := firingLines * uint32(len(availableTargets))
 
-			reporter := newShootingReporter(firingLines, penaltyLapLen)
+			reporter := newShootingReporter(targetsPerFiringLine, penaltyLapLen, nil, nil)
 
 			reporter.NotifyWithEvent(event.Event{ID: event.CompetitorOnFiringRange})
 			reporter.NotifyWithEvent(event.Event{ID: event.TargetHit, Extra: "1"})
@@ -222,9 +229,9 @@ func Test_shootingReporter(t *testing.T) {
 		t.Run("and was running penalty lap", func(t *testing.T) {
 			t.Parallel()
 
-			totalTargets := firingLines * uint32(len(event.AvailableTargets))
+			totalTargets := firingLines * uint32(len(availableTargets))
 
-			reporter := newShootingReporter(firingLines, penaltyLapLen)
+			reporter := newShootingReporter(targetsPerFiringLine, penaltyLapLen, nil, nil)
 
 			reporter.NotifyWithEvent(event.Event{ID: event.CompetitorOnFiringRange})
 			reporter.NotifyWithEvent(event.Event{ID: event.TargetHit, Extra: "1"})
@@ -256,3 +263,199 @@ func Test_shootingReporter(t *testing.T) {
 		})
 	})
 }
+
+func Test_shootingReporter_withFiringLineLaps(t *testing.T) {
+	const (
+		firingLines   uint32 = 2
+		penaltyLapLen uint32 = 100
+	)
+
+	targetsPerFiringLine := slices.Repeat([]uint32{uint32(len(availableTargets))}, int(firingLines))
+
+	shoot := func(reporter *shootingReporter, firingLine string, targets ...string) {
+		reporter.NotifyWithEvent(event.Event{ID: event.CompetitorOnFiringRange, Extra: firingLine})
+		for _, target := range targets {
+			reporter.NotifyWithEvent(event.Event{ID: event.TargetHit, Extra: target})
+		}
+		reporter.NotifyWithEvent(event.Event{ID: event.CompetitorLeftFiringRange})
+	}
+
+	t.Run("with firing lines in expected laps", func(t *testing.T) {
+		t.Parallel()
+
+		reporter := newShootingReporter(targetsPerFiringLine, penaltyLapLen, []uint32{1, 3}, nil)
+
+		shoot(reporter, "1", "1", "2")
+		reporter.NotifyWithEvent(event.Event{ID: event.CompetitorEndedMainLap})
+		reporter.NotifyWithEvent(event.Event{ID: event.CompetitorEndedMainLap})
+		shoot(reporter, "2", "1", "2", "3")
+
+		info := reporter.GetInfo()
+		assert.Equal(t, uint32(10), info.TotalTargets)
+		assert.Equal(t, uint32(5), info.TotalHitTargets)
+	})
+
+	t.Run("with firing lines in unexpected laps", func(t *testing.T) {
+		t.Parallel()
+
+		reporter := newShootingReporter(targetsPerFiringLine, penaltyLapLen, []uint32{1, 3}, nil)
+
+		shoot(reporter, "2", "1", "2")
+		reporter.NotifyWithEvent(event.Event{ID: event.CompetitorEndedMainLap})
+		shoot(reporter, "2", "1", "2", "3")
+		shoot(reporter, "1", "1", "2", "3")
+		shoot(reporter, "abra", "1", "2", "3")
+
+		info := reporter.GetInfo()
+		assert.Equal(t, uint32(0), info.TotalHitTargets)
+	})
+
+	t.Run("with skipped firing line", func(t *testing.T) {
+		t.Parallel()
+
+		reporter := newShootingReporter(targetsPerFiringLine, penaltyLapLen, []uint32{1, 2}, nil)
+
+		reporter.NotifyWithEvent(event.Event{ID: event.CompetitorEndedMainLap})
+		shoot(reporter, "2", "1", "2", "3")
+		shoot(reporter, "2", "4")
+		shoot(reporter, "1", "5")
+
+		info := reporter.GetInfo()
+		assert.Equal(t, uint32(3), info.TotalHitTargets)
+	})
+}
+
+func Test_shootingReporter_withDifferentTargets(t *testing.T) {
+	const penaltyLapLen uint32 = 100
+
+	reporter := newShootingReporter([]uint32{3, 5}, penaltyLapLen, nil, nil)
+
+	enterPenalty := time.Date(0, time.January, 1, 10, 30, 0, 0, time.UTC)
+	leavePenalty := enterPenalty.Add(time.Minute)
+
+	reporter.NotifyWithEvent(event.Event{ID: event.CompetitorOnFiringRange, Extra: "1"})
+	reporter.NotifyWithEvent(event.Event{ID: event.TargetHit, Extra: "1"})
+	reporter.NotifyWithEvent(event.Event{ID: event.TargetHit, Extra: "4"})
+	reporter.NotifyWithEvent(event.Event{ID: event.TargetHit, Extra: "5"})
+	reporter.NotifyWithEvent(event.Event{ID: event.CompetitorLeftFiringRange})
+
+	reporter.NotifyWithEvent(event.Event{Time: enterPenalty, ID: event.CompetitorEnterPenaltyLaps})
+	reporter.NotifyWithEvent(event.Event{Time: leavePenalty, ID: event.CompetitorLeftPenaltyLaps})
+
+	reporter.NotifyWithEvent(event.Event{ID: event.CompetitorOnFiringRange, Extra: "2"})
+	for _, target := range availableTargets {
+		reporter.NotifyWithEvent(event.Event{ID: event.TargetHit, Extra: target})
+	}
+	reporter.NotifyWithEvent(event.Event{ID: event.CompetitorLeftFiringRange})
+
+	info := reporter.GetInfo()
+	assert.Equal(t, uint32(8), info.TotalTargets)
+	assert.Equal(t, uint32(6), info.TotalHitTargets)
+	assert.Equal(t, float64(2*penaltyLapLen)/time.Minute.Seconds(), info.AverageSpeedOnPenaltyLaps)
+}
+
+func Test_shootingReporter_withShots(t *testing.T) {
+	reporter := newShootingReporter([]uint32{5, 5}, 100, nil, nil)
+
+	arrival := time.Date(0, time.January, 1, 10, 30, 0, 0, time.UTC)
+
+	reporter.NotifyWithEvent(event.Event{Time: arrival, ID: event.CompetitorOnFiringRange, Extra: "1"})
+	reporter.NotifyWithEvent(event.Event{Time: arrival.Add(20 * time.Second), ID: event.ShotFired, Extra: "1 hit"})
+	reporter.NotifyWithEvent(event.Event{Time: arrival.Add(23 * time.Second), ID: event.ShotFired, Extra: "2 miss"})
+	reporter.NotifyWithEvent(event.Event{Time: arrival.Add(26 * time.Second), ID: event.ShotFired, Extra: "3 hit"})
+	reporter.NotifyWithEvent(event.Event{Time: arrival.Add(29 * time.Second), ID: event.ShotFired, Extra: "6 hit"})
+	reporter.NotifyWithEvent(event.Event{Time: arrival.Add(32 * time.Second), ID: event.ShotFired, Extra: "5 miss"})
+	reporter.NotifyWithEvent(event.Event{Time: arrival.Add(35 * time.Second), ID: event.ShotFired, Extra: "4 miss"})
+	reporter.NotifyWithEvent(event.Event{ID: event.CompetitorLeftFiringRange})
+
+	reporter.NotifyWithEvent(event.Event{ID: event.CompetitorOnFiringRange, Extra: "2"})
+	reporter.NotifyWithEvent(event.Event{ID: event.CompetitorLeftFiringRange})
+
+	info := reporter.GetInfo()
+	assert.Equal(t, []stageInfo{
+		{
+			FirstShotTime: 20 * time.Second,
+			Rhythm:        3750 * time.Millisecond,
+			Shots:         5,
+			MissedShots:   []uint32{2, 4, 5},
+		},
+		{
+			MissedShots: []uint32{},
+		},
+	}, info.Stages)
+	assert.Equal(t, "{00:00:20.000, 00:00:03.750, 2/5, [2, 4, 5]}", info.Stages[0].String())
+	assert.Equal(t, "{,}", info.Stages[1].String())
+	assert.Equal(t, uint32(2), info.TotalHitTargets)
+}
+
+func Test_shootingReporter_withShotsAndHitTargets(t *testing.T) {
+	reporter := newShootingReporter([]uint32{5}, 100, nil, nil)
+
+	arrival := time.Date(0, time.January, 1, 10, 30, 0, 0, time.UTC)
+
+	reporter.NotifyWithEvent(event.Event{Time: arrival, ID: event.CompetitorOnFiringRange, Extra: "1"})
+	reporter.NotifyWithEvent(event.Event{ID: event.ShotFired, Extra: "1 hit"})
+	reporter.NotifyWithEvent(event.Event{ID: event.TargetHit, Extra: "1"})
+	reporter.NotifyWithEvent(event.Event{ID: event.ShotFired, Extra: "2 hit"})
+	reporter.NotifyWithEvent(event.Event{ID: event.ShotFired, Extra: "3 miss"})
+	reporter.NotifyWithEvent(event.Event{ID: event.ShotFired, Extra: "4 miss"})
+	reporter.NotifyWithEvent(event.Event{ID: event.ShotFired, Extra: "5 miss"})
+	reporter.NotifyWithEvent(event.Event{ID: event.CompetitorLeftFiringRange})
+	reporter.NotifyWithEvent(event.Event{Time: arrival, ID: event.CompetitorEnterPenaltyLaps})
+	reporter.NotifyWithEvent(event.Event{Time: arrival.Add(time.Minute), ID: event.CompetitorLeftPenaltyLaps})
+
+	info := reporter.GetInfo()
+	assert.Equal(t, uint32(2), info.TotalHitTargets)
+	assert.Equal(t, "{00:01:00.000, 5.000} 2/5", info.String())
+	assert.Equal(t, []uint32{3, 4, 5}, info.Stages[0].MissedShots)
+}
+
+func Test_shootingReporter_withPositions(t *testing.T) {
+	positions := []string{config.PositionProne, config.PositionStanding, config.PositionProne, config.PositionStanding}
+	reporter := newShootingReporter([]uint32{5, 5, 5, 5}, 100, nil, positions)
+
+	arrival := time.Date(0, time.January, 1, 10, 30, 0, 0, time.UTC)
+
+	hits := [][]string{{"1", "2", "3", "4", "5"}, {"1", "3"}, {"2", "3", "4"}}
+	rangeTimes := []time.Duration{time.Minute, 50 * time.Second, 40 * time.Second}
+
+	for i := range hits {
+		reporter.NotifyWithEvent(event.Event{Time: arrival, ID: event.CompetitorOnFiringRange, Extra: fmt.Sprint(i + 1)})
+		for _, target := range hits[i] {
+			reporter.NotifyWithEvent(event.Event{ID: event.TargetHit, Extra: target})
+		}
+		reporter.NotifyWithEvent(event.Event{Time: arrival.Add(rangeTimes[i]), ID: event.CompetitorLeftFiringRange})
+	}
+
+	reporter.NotifyWithEvent(event.Event{Time: arrival, ID: event.CompetitorOnFiringRange, Extra: "4"})
+	reporter.NotifyWithEvent(event.Event{ID: event.TargetHit, Extra: "1"})
+
+	info := reporter.GetInfo()
+	assert.Equal(t, []positionInfo{
+		{Position: config.PositionProne, Targets: 10, Hits: 8, RangeTime: time.Minute + 40*time.Second},
+		{Position: config.PositionStanding, Targets: 5, Hits: 2, RangeTime: 50 * time.Second},
+	}, info.Positions)
+	assert.Equal(t, 80.0, info.Positions[0].Accuracy())
+	assert.Equal(t, "{standing, 2/5, 40.0%, 00:00:50.000}", info.Positions[1].String())
+
+	assert.Nil(t, newShootingReporter([]uint32{5}, 100, nil, nil).GetInfo().Positions)
+}
+
+func Test_shootingReporter_withFiringLinesNotInOrder(t *testing.T) {
+	positions := []string{config.PositionProne, config.PositionStanding}
+	reporter := newShootingReporter([]uint32{5, 3}, 100, nil, positions)
+
+	arrival := time.Date(0, time.January, 1, 10, 30, 0, 0, time.UTC)
+
+	reporter.NotifyWithEvent(event.Event{Time: arrival, ID: event.CompetitorOnFiringRange, Extra: "2"})
+	reporter.NotifyWithEvent(event.Event{ID: event.TargetHit, Extra: "1"})
+	reporter.NotifyWithEvent(event.Event{ID: event.TargetHit, Extra: "4"})
+	reporter.NotifyWithEvent(event.Event{Time: arrival.Add(time.Minute), ID: event.CompetitorLeftFiringRange})
+
+	info := reporter.GetInfo()
+	assert.Equal(t, uint32(1), info.TotalHitTargets)
+	assert.Equal(t, []positionInfo{
+		{Position: config.PositionProne},
+		{Position: config.PositionStanding, Targets: 3, Hits: 1, RangeTime: time.Minute},
+	}, info.Positions)
+}
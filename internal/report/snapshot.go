@@ -0,0 +1,257 @@
+package report
+
+import (
+	"maps"
+	"slices"
+	"time"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event/parser"
+)
+
+// ReporterSnapshot is serializable state of Reporter, see Reporter.Snapshot.
+type ReporterSnapshot struct {
+	// Competitors - state of reporters of each registered competitor by competitorID.
+	Competitors map[string]CompetitorReporterSnapshot `json:"competitors"`
+}
+
+// CompetitorReporterSnapshot is serializable state of reporters of single competitor.
+type CompetitorReporterSnapshot struct {
+	TotalTime TotalTimeSnapshot `json:"totalTime"`
+	LapsTime  LapsTimeSnapshot  `json:"lapsTime"`
+	Shooting  ShootingSnapshot  `json:"shooting"`
+	// Checkpoint - the last checkpoint, nil if the competitor has not started.
+	Checkpoint *CheckpointSnapshot `json:"checkpoint,omitempty"`
+}
+
+// CheckpointSnapshot is serializable the last checkpoint of the competitor.
+type CheckpointSnapshot struct {
+	Time          time.Time     `json:"time"`
+	ID            event.EventID `json:"id"`
+	LapsCompleted uint32        `json:"lapsCompleted"`
+	FiringLine    string        `json:"firingLine,omitempty"`
+}
+
+// TotalTimeSnapshot is serializable state of total time calculation.
+type TotalTimeSnapshot struct {
+	State            string                  `json:"state"`
+	Disqualification *event.Disqualification `json:"disqualification,omitempty"`
+	Start            time.Time               `json:"start"`
+	End              time.Time               `json:"end"`
+	Adjustment       time.Duration           `json:"adjustment"`
+	Corrections      []event.Event           `json:"corrections,omitempty"`
+}
+
+// LapsTimeSnapshot is serializable state of main laps time calculation.
+type LapsTimeSnapshot struct {
+	LapsCompleted uint32          `json:"lapsCompleted"`
+	LapStart      time.Time       `json:"lapStart"`
+	Started       bool            `json:"started"`
+	LapTimes      []time.Duration `json:"lapTimes"`
+	Stop          bool            `json:"stop"`
+}
+
+// ShootingSnapshot is serializable state of shooting and penalty laps calculation.
+type ShootingSnapshot struct {
+	State                            uint8                      `json:"state"`
+	HitTargetsOnCurrentFireRange     []uint32                   `json:"hitTargetsOnCurrentFireRange,omitempty"`
+	TargetsOnCurrentFireRange        uint32                     `json:"targetsOnCurrentFireRange"`
+	TotalHitTargets                  uint32                     `json:"totalHitTargets"`
+	FiringLines                      parser.FiringLinesSnapshot `json:"firingLines"`
+	TotalPenaltyLapCount             uint32                     `json:"totalPenaltyLapCount"`
+	PenaltyLapToPerformAfterShooting uint8                      `json:"penaltyLapToPerformAfterShooting"`
+	TimeSpentOnPenaltyLaps           time.Duration              `json:"timeSpentOnPenaltyLaps"`
+	EnterPenaltyLap                  time.Time                  `json:"enterPenaltyLap"`
+	Stages                           []StageSnapshot            `json:"stages,omitempty"`
+}
+
+// StageSnapshot is serializable single visit of the firing range.
+type StageSnapshot struct {
+	FiringLine uint32         `json:"firingLine"`
+	Targets    uint32         `json:"targets"`
+	Hits       uint32         `json:"hits"`
+	Arrival    time.Time      `json:"arrival"`
+	Departure  time.Time      `json:"departure"`
+	Shots      []ShotSnapshot `json:"shots,omitempty"`
+}
+
+// ShotSnapshot is serializable single fired shot.
+type ShotSnapshot struct {
+	Time   time.Time `json:"time"`
+	Number uint32    `json:"number"`
+	Hit    bool      `json:"hit"`
+}
+
+// Snapshot returns current state of r, that can be restored later with Restore.
+func (r *Reporter) Snapshot() ReporterSnapshot {
+	snapshot := ReporterSnapshot{
+		Competitors: make(map[string]CompetitorReporterSnapshot, len(r.reporters)),
+	}
+
+	for competitorID, reporter := range r.reporters {
+		competitorSnapshot := CompetitorReporterSnapshot{
+			TotalTime: reporter.totalTime.snapshot(),
+			LapsTime:  reporter.lapsTime.snapshot(),
+			Shooting:  reporter.shooting.snapshot(),
+		}
+
+		if last, ok := reporter.checkpoint.GetCheckpoint(); ok {
+			competitorSnapshot.Checkpoint = &CheckpointSnapshot{
+				Time:          last.Time,
+				ID:            last.ID,
+				LapsCompleted: last.LapsCompleted,
+				FiringLine:    last.FiringLine,
+			}
+		}
+
+		snapshot.Competitors[competitorID] = competitorSnapshot
+	}
+
+	return snapshot
+}
+
+// Restore replaces state of r with the given snapshot. r must be created with the same config
+// as the Reporter the snapshot is taken from.
+func (r *Reporter) Restore(snapshot ReporterSnapshot) {
+	clear(r.reporters)
+
+	for competitorID, competitorSnapshot := range snapshot.Competitors {
+		reporter := newCompetitorReporter(r.conf)
+		reporter.totalTime.restore(competitorSnapshot.TotalTime)
+		reporter.lapsTime.restore(competitorSnapshot.LapsTime)
+		reporter.shooting.restore(competitorSnapshot.Shooting)
+
+		if competitorSnapshot.Checkpoint != nil {
+			reporter.checkpoint.last = &checkpoint{
+				Time:          competitorSnapshot.Checkpoint.Time,
+				ID:            competitorSnapshot.Checkpoint.ID,
+				LapsCompleted: competitorSnapshot.Checkpoint.LapsCompleted,
+				FiringLine:    competitorSnapshot.Checkpoint.FiringLine,
+			}
+		}
+
+		r.reporters[competitorID] = reporter
+	}
+}
+
+func (tt *totalTimeReporter) snapshot() TotalTimeSnapshot {
+	snapshot := TotalTimeSnapshot{
+		State:       string(tt.state),
+		Start:       tt.start,
+		End:         tt.end,
+		Adjustment:  tt.adjustment,
+		Corrections: slices.Clone(tt.corrections),
+	}
+
+	if tt.disqualification != nil {
+		disqualification := *tt.disqualification
+		snapshot.Disqualification = &disqualification
+	}
+
+	return snapshot
+}
+
+func (tt *totalTimeReporter) restore(snapshot TotalTimeSnapshot) {
+	tt.state = totalTimeReporterCompetitorState(snapshot.State)
+	tt.start = snapshot.Start
+	tt.end = snapshot.End
+	tt.adjustment = snapshot.Adjustment
+	tt.corrections = slices.Clone(snapshot.Corrections)
+
+	if snapshot.Disqualification != nil {
+		disqualification := *snapshot.Disqualification
+		tt.disqualification = &disqualification
+	}
+}
+
+func (lt *lapsTimeReporter) snapshot() LapsTimeSnapshot {
+	return LapsTimeSnapshot{
+		LapsCompleted: lt.lapsCompleted,
+		LapStart:      lt.lapStart,
+		Started:       lt.started,
+		LapTimes:      slices.Clone(lt.lapTimes),
+		Stop:          lt.stop,
+	}
+}
+
+func (lt *lapsTimeReporter) restore(snapshot LapsTimeSnapshot) {
+	lt.lapsCompleted = snapshot.LapsCompleted
+	lt.lapStart = snapshot.LapStart
+	lt.started = snapshot.Started
+	lt.stop = snapshot.Stop
+
+	copy(lt.lapTimes, snapshot.LapTimes)
+}
+
+func (s *shootingReporter) snapshot() ShootingSnapshot {
+	snapshot := ShootingSnapshot{
+		State:                            uint8(s.state),
+		HitTargetsOnCurrentFireRange:     slices.Sorted(maps.Keys(s.hitTargetsOnCurrentFireRange)),
+		TargetsOnCurrentFireRange:        s.targetsOnCurrentFireRange,
+		TotalHitTargets:                  s.totalNumberOfHitTarges,
+		FiringLines:                      s.firingLines.Snapshot(),
+		TotalPenaltyLapCount:             s.totalPenaltyLapCount,
+		PenaltyLapToPerformAfterShooting: s.penaltyLapToPerformAfterShooting,
+		TimeSpentOnPenaltyLaps:           s.timeSpentOnPenaltyLaps,
+		EnterPenaltyLap:                  s.enterPenaltyLap,
+		Stages:                           make([]StageSnapshot, 0, len(s.stages)),
+	}
+
+	for _, stage := range s.stages {
+		stageSnapshot := StageSnapshot{
+			FiringLine: stage.firingLine,
+			Targets:    stage.targets,
+			Hits:       stage.hits,
+			Arrival:    stage.arrival,
+			Departure:  stage.departure,
+			Shots:      make([]ShotSnapshot, 0, len(stage.shots)),
+		}
+
+		for _, shot := range stage.shots {
+			stageSnapshot.Shots = append(stageSnapshot.Shots, ShotSnapshot{
+				Time:   shot.time,
+				Number: shot.shot.Number,
+				Hit:    shot.shot.Hit,
+			})
+		}
+
+		snapshot.Stages = append(snapshot.Stages, stageSnapshot)
+	}
+
+	return snapshot
+}
+
+func (s *shootingReporter) restore(snapshot ShootingSnapshot) {
+	s.state = shootingReporterCompetitorState(snapshot.State)
+	s.targetsOnCurrentFireRange = snapshot.TargetsOnCurrentFireRange
+	s.totalNumberOfHitTarges = snapshot.TotalHitTargets
+	s.firingLines.Restore(snapshot.FiringLines)
+	s.totalPenaltyLapCount = snapshot.TotalPenaltyLapCount
+	s.penaltyLapToPerformAfterShooting = snapshot.PenaltyLapToPerformAfterShooting
+	s.timeSpentOnPenaltyLaps = snapshot.TimeSpentOnPenaltyLaps
+	s.enterPenaltyLap = snapshot.EnterPenaltyLap
+
+	for _, target := range snapshot.HitTargetsOnCurrentFireRange {
+		s.hitTargetsOnCurrentFireRange[target] = struct{}{}
+	}
+
+	s.stages = make([]shootingStage, 0, len(snapshot.Stages))
+	for _, stageSnapshot := range snapshot.Stages {
+		stage := shootingStage{
+			firingLine: stageSnapshot.FiringLine,
+			targets:    stageSnapshot.Targets,
+			hits:       stageSnapshot.Hits,
+			arrival:    stageSnapshot.Arrival,
+			departure:  stageSnapshot.Departure,
+		}
+
+		for _, shot := range stageSnapshot.Shots {
+			stage.shots = append(stage.shots, firedShot{
+				time: shot.Time,
+				shot: parser.Shot{Number: shot.Number, Hit: shot.Hit},
+			})
+		}
+
+		s.stages = append(s.stages, stage)
+	}
+}
@@ -0,0 +1,30 @@
+package report
+
+import "slices"
+
+// Status is the final status of the competitor.
+type Status string
+
+// constants define possible final statuses of competitors.
+const (
+	// StatusFinished - the competitor has completed all laps.
+	StatusFinished Status = "Finished"
+	// StatusDNS - the competitor has not started.
+	StatusDNS Status = "NotStarted"
+	// StatusDNF - the competitor has started, but has not completed all laps.
+	StatusDNF Status = "NotFinished"
+	// StatusDSQ - the competitor has been disqualified.
+	StatusDSQ Status = "DSQ"
+	// StatusLAP - the competitor has been lapped by the leader and pulled out of the race.
+	StatusLAP Status = "LAP"
+	// StatusRunning - the competitor is still on course. Used only in provisional report.
+	StatusRunning Status = "Running"
+)
+
+// statusOrder defines order of statuses in sorted Report.
+var statusOrder = []Status{StatusDNS, StatusFinished, StatusRunning, StatusLAP, StatusDNF, StatusDSQ}
+
+// compareStatuses compares statuses according to statusOrder.
+func compareStatuses(first, second Status) int {
+	return slices.Index(statusOrder, first) - slices.Index(statusOrder, second)
+}
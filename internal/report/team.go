@@ -0,0 +1,177 @@
+package report
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/config"
+)
+
+// TeamRankingTitle is the title of team ranking.
+const TeamRankingTitle = "Teams"
+
+// incompleteTeam is displayed instead of total time for teams with not enough finishers.
+const incompleteTeam = "Incomplete"
+
+// TeamRanking is a sorted list of teams results, aggregated from individual results.
+type TeamRanking []teamRecord
+
+type teamRecord struct {
+	// place of the team, zero if the team is not ranked.
+	place     int
+	team      string
+	totalTime time.Duration
+	complete  bool
+	counted   []reportRecord
+}
+
+// TeamRanking groups records by team and creates TeamRanking. Team total time is the sum
+// of total times of the best rules.BestN finishers of the team (all finishers if BestN is zero).
+// Teams with less than rules.BestN finishers are placed after other teams and are not ranked.
+// Teams, which are not separated by total time and rules.TieBreak, share the same place.
+// Competitors without team are ignored.
+func (report Report) TeamRanking(rules config.TeamRanking) TeamRanking {
+	byTeam := make(map[string][]reportRecord)
+	for _, record := range report {
+		if record.team == "" {
+			continue
+		}
+
+		if _, ok := byTeam[record.team]; !ok {
+			byTeam[record.team] = make([]reportRecord, 0)
+		}
+
+		if record.finalState == StatusFinished {
+			byTeam[record.team] = append(byTeam[record.team], record)
+		}
+	}
+
+	ranking := make(TeamRanking, 0, len(byTeam))
+	for team, finishers := range byTeam {
+		slices.SortFunc(finishers, func(first, second reportRecord) int {
+			if res := cmp.Compare(first.totalTime, second.totalTime); res != 0 {
+				return res
+			}
+
+			return strings.Compare(first.competitorID, second.competitorID)
+		})
+
+		counted := finishers
+		complete := len(finishers) > 0
+		if rules.BestN != 0 {
+			complete = uint32(len(finishers)) >= rules.BestN
+			counted = finishers[:min(uint32(len(finishers)), rules.BestN)]
+		}
+
+		record := teamRecord{
+			team:     team,
+			complete: complete,
+			counted:  counted,
+		}
+
+		for _, competitor := range counted {
+			record.totalTime += competitor.totalTime
+		}
+
+		ranking = append(ranking, record)
+	}
+
+	slices.SortFunc(ranking, func(first, second teamRecord) int {
+		if res := compareTeamResults(first, second, rules.TieBreak); res != 0 {
+			return res
+		}
+
+		return strings.Compare(first.team, second.team)
+	})
+
+	places := sharedPlaces(len(ranking),
+		func(i int) bool {
+			return ranking[i].complete
+		},
+		func(i int) bool {
+			return compareTeamResults(ranking[i-1], ranking[i], rules.TieBreak) == 0
+		})
+
+	for i := range ranking {
+		ranking[i].place = places[i]
+	}
+
+	return ranking
+}
+
+// compareTeamResults compares results of teams, zero means that teams are tied.
+func compareTeamResults(first, second teamRecord, tieBreak string) int {
+	if first.complete != second.complete {
+		if first.complete {
+			return -1
+		}
+
+		return 1
+	}
+
+	if !first.complete {
+		if res := cmp.Compare(len(second.counted), len(first.counted)); res != 0 {
+			return res
+		}
+	}
+
+	if res := cmp.Compare(first.totalTime, second.totalTime); res != 0 {
+		return res
+	}
+
+	if len(first.counted) != 0 && len(second.counted) != 0 {
+		firstDecisive, secondDecisive := first.counted[0], second.counted[0]
+		if tieBreak == config.TieBreakLastCounting {
+			firstDecisive, secondDecisive = first.counted[len(first.counted)-1], second.counted[len(second.counted)-1]
+		}
+
+		return cmp.Compare(firstDecisive.totalTime, secondDecisive.totalTime)
+	}
+
+	return 0
+}
+
+// String formats TeamRanking and returns it as string.
+// The first line is a title, each next line is related to unique team
+// and contains ids of counted competitors.
+// Examples of formatted lines:
+//
+//	Teams
+//	1 +00:00:00.000 [01:29:03.872] RUS 1, 4
+//	2 +00:00:10.110 [01:29:13.982] NOR 2, 3
+//	- - [Incomplete] GER 5
+func (ranking TeamRanking) String() string {
+	builder := strings.Builder{}
+
+	_, _ = builder.WriteString(TeamRankingTitle)
+	_, _ = builder.WriteString("\n")
+
+	for _, record := range ranking {
+		placeValue, gapValue, totalTimeValue := "-", "-", incompleteTeam
+
+		if record.complete {
+			placeValue = fmt.Sprint(record.place)
+			gapValue = "+" + formatDuration(record.totalTime-ranking[0].totalTime)
+			totalTimeValue = formatDuration(record.totalTime)
+		}
+
+		line := fmt.Sprintf("%s %s [%s] %s", placeValue, gapValue, totalTimeValue, record.team)
+
+		competitorIDs := make([]string, 0, len(record.counted))
+		for _, competitor := range record.counted {
+			competitorIDs = append(competitorIDs, competitor.competitorID)
+		}
+
+		if len(competitorIDs) != 0 {
+			line += " " + strings.Join(competitorIDs, ", ")
+		}
+
+		_, _ = builder.WriteString(line)
+		_, _ = builder.WriteString("\n")
+	}
+
+	return builder.String()
+}
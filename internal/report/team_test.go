@@ -0,0 +1,90 @@
+package report
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_Report_TeamRanking(t *testing.T) {
+	givenReport := Report([]reportRecord{
+		{totalTime: time.Minute * 30, finalState: StatusFinished, competitorID: "1", team: "RUS"},
+		{totalTime: time.Minute * 32, finalState: StatusFinished, competitorID: "2", team: "RUS"},
+		{totalTime: time.Minute * 40, finalState: StatusFinished, competitorID: "3", team: "RUS"},
+		{totalTime: time.Minute * 31, finalState: StatusFinished, competitorID: "4", team: "NOR"},
+		{totalTime: time.Minute * 31, finalState: StatusFinished, competitorID: "5", team: "NOR"},
+		{finalState: StatusDNF, competitorID: "6", team: "NOR"},
+		{totalTime: time.Minute * 29, finalState: StatusFinished, competitorID: "7", team: "GER"},
+		{finalState: StatusDNS, competitorID: "8", team: "GER"},
+		{finalState: StatusDNS, competitorID: "9", team: "FRA"},
+		{totalTime: time.Minute * 20, finalState: StatusFinished, competitorID: "10"},
+	})
+
+	t.Run("with best individual tie-break", func(t *testing.T) {
+		ranking := givenReport.TeamRanking(config.TeamRanking{BestN: 2})
+
+		assert.Equal(t,
+			strings.Join([]string{
+				"Teams",
+				"1 +00:00:00.000 [01:02:00.000] RUS 1, 2",
+				"2 +00:00:00.000 [01:02:00.000] NOR 4, 5",
+				"- - [Incomplete] GER 7",
+				"- - [Incomplete] FRA",
+			}, "\n")+"\n",
+			ranking.String())
+	})
+
+	t.Run("with last counting tie-break", func(t *testing.T) {
+		ranking := givenReport.TeamRanking(config.TeamRanking{
+			BestN:    2,
+			TieBreak: config.TieBreakLastCounting,
+		})
+
+		assert.Equal(t, "NOR", ranking[0].team)
+		assert.Equal(t, "RUS", ranking[1].team)
+	})
+
+	t.Run("with tied teams", func(t *testing.T) {
+		tiedReport := Report([]reportRecord{
+			{totalTime: time.Minute * 30, finalState: StatusFinished, competitorID: "1", team: "RUS"},
+			{totalTime: time.Minute * 32, finalState: StatusFinished, competitorID: "2", team: "RUS"},
+			{totalTime: time.Minute * 30, finalState: StatusFinished, competitorID: "3", team: "NOR"},
+			{totalTime: time.Minute * 32, finalState: StatusFinished, competitorID: "4", team: "NOR"},
+			{totalTime: time.Minute * 31, finalState: StatusFinished, competitorID: "5", team: "GER"},
+			{totalTime: time.Minute * 33, finalState: StatusFinished, competitorID: "6", team: "GER"},
+		})
+
+		ranking := tiedReport.TeamRanking(config.TeamRanking{BestN: 2})
+
+		assert.Equal(t,
+			strings.Join([]string{
+				"Teams",
+				"1 +00:00:00.000 [01:02:00.000] NOR 3, 4",
+				"1 +00:00:00.000 [01:02:00.000] RUS 1, 2",
+				"3 +00:02:00.000 [01:04:00.000] GER 5, 6",
+			}, "\n")+"\n",
+			ranking.String())
+
+		results := ranking.jsonResults()
+		assert.Equal(t, 1, results[0].Place)
+		assert.Equal(t, 1, results[1].Place)
+		assert.Equal(t, 3, results[2].Place)
+	})
+
+	t.Run("with all finishers counted", func(t *testing.T) {
+		ranking := givenReport.TeamRanking(config.TeamRanking{})
+
+		assert.Equal(t,
+			strings.Join([]string{
+				"Teams",
+				"1 +00:00:00.000 [00:29:00.000] GER 7",
+				"2 +00:33:00.000 [01:02:00.000] NOR 4, 5",
+				"3 +01:13:00.000 [01:42:00.000] RUS 1, 2, 3",
+				"- - [Incomplete] FRA",
+			}, "\n")+"\n",
+			ranking.String())
+	})
+}
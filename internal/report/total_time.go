@@ -10,18 +10,24 @@ import (
 type totalTimeReporterCompetitorState string
 
 const (
-	initial     totalTimeReporterCompetitorState = "Initial"
-	running     totalTimeReporterCompetitorState = "Running"
-	notStarted  totalTimeReporterCompetitorState = "NotStarted"
-	notFinished totalTimeReporterCompetitorState = "NotFinished"
-	finished    totalTimeReporterCompetitorState = "Finished"
+	initial        totalTimeReporterCompetitorState = "Initial"
+	running        totalTimeReporterCompetitorState = "Running"
+	cannotContinue totalTimeReporterCompetitorState = "CannotContinue"
+	lapped         totalTimeReporterCompetitorState = "Lapped"
+	finished       totalTimeReporterCompetitorState = "Finished"
 )
 
 // totalTimeReporter calculates total time of single competitor.
+// Time penalties and bonuses given by the jury are added to total time.
+// Finish time is the end of the last main lap, as finish of the competitor reinstated by the jury
+// is announced at the time of reinstatement.
 type totalTimeReporter struct {
-	state totalTimeReporterCompetitorState
-	start time.Time
-	end   time.Time
+	state            totalTimeReporterCompetitorState
+	disqualification *event.Disqualification
+	start            time.Time
+	end              time.Time
+	adjustment       time.Duration
+	corrections      []event.Event
 }
 
 func newTotalTimeReporter() *totalTimeReporter {
@@ -31,13 +37,20 @@ func newTotalTimeReporter() *totalTimeReporter {
 }
 
 func (tt *totalTimeReporter) NotifyWithEvent(e event.Event) {
+	if event.IsJuryCorrection(e.ID) {
+		tt.onCorrection(e)
+		return
+	}
+
 	if tt.state == initial && e.ID == event.StartTimeAssignment {
 		tt.start, _ = parser.ParseTime(e.Extra)
 		return
 	}
 
-	if (tt.state == initial || tt.state == running) && e.ID == event.CompetitorDisqualified {
-		tt.state = notStarted
+	if e.ID == event.CompetitorDisqualified {
+		disqualification := event.ParseDisqualification(e.Extra)
+		tt.disqualification = &disqualification
+
 		return
 	}
 
@@ -46,33 +59,84 @@ func (tt *totalTimeReporter) NotifyWithEvent(e event.Event) {
 		return
 	}
 
-	if tt.state == running && e.ID == event.CompetitorFinished {
+	if tt.state == running && e.ID == event.CompetitorEndedMainLap {
 		tt.end = e.Time
+		return
+	}
+
+	// finish of the competitor reinstated by the jury is announced later than the end of the last lap,
+	// so the end of the last lap is used, if it is known.
+	if tt.state == running && e.ID == event.CompetitorFinished {
+		if tt.end.IsZero() {
+			tt.end = e.Time
+		}
+
 		tt.state = finished
+
+		return
+	}
+
+	if tt.state == running && e.ID == event.CompetitorLapped {
+		tt.state = lapped
 		return
 	}
 
 	if tt.state == running && e.ID == event.CompetitorCannotContinue {
-		tt.state = notFinished
+		tt.state = cannotContinue
 		return
 	}
 }
 
+// onCorrection remembers jury correction and applies time penalty or bonus.
+// Disqualification and reinstatement are applied on CompetitorDisqualified and
+// JuryReinstatement events.
+func (tt *totalTimeReporter) onCorrection(e event.Event) {
+	tt.corrections = append(tt.corrections, e)
+
+	switch e.ID {
+	case event.JuryTimePenalty:
+		correction, _ := parser.ParseTimeCorrection(e.Extra)
+		tt.adjustment += correction.Duration
+	case event.JuryTimeBonus:
+		correction, _ := parser.ParseTimeCorrection(e.Extra)
+		tt.adjustment -= correction.Duration
+	case event.JuryReinstatement:
+		tt.disqualification = nil
+	}
+}
+
 // GetTotalTime returns:
-//   - Calculated total time (time interval between scheduled start for competitor and time of completing last lap).
-//   - Final state of the competitor (on of: notStarted, notFinished, finished).
-func (tt *totalTimeReporter) GetTotalTime() (time.Duration, totalTimeReporterCompetitorState) {
-	if tt.state == finished {
-		return tt.end.Sub(tt.start), finished
+//   - Calculated total time (time interval between scheduled start for competitor and time of completing last lap
+//     with time penalties and bonuses given by the jury). It is zero if the competitor has not finished.
+//   - Final status of the competitor. Disqualified competitor has StatusDSQ regardless of other events,
+//     its reason is returned by Disqualification.
+func (tt *totalTimeReporter) GetTotalTime() (time.Duration, Status) {
+	if tt.disqualification != nil {
+		return 0, StatusDSQ
 	}
 
-	if tt.state != notStarted && tt.state != notFinished {
-		if tt.state == running {
-			tt.state = notFinished
-		} else {
-			tt.state = notStarted
-		}
+	switch tt.state {
+	case finished:
+		return max(tt.end.Sub(tt.start)+tt.adjustment, 0), StatusFinished
+	case lapped:
+		return 0, StatusLAP
+	case running, cannotContinue:
+		return 0, StatusDNF
+	default:
+		return 0, StatusDNS
+	}
+}
+
+// Running checks if the competitor is still on course.
+func (tt *totalTimeReporter) Running() bool {
+	return tt.state == running
+}
+
+// Disqualification returns reason of disqualification, if the competitor is disqualified.
+func (tt *totalTimeReporter) Disqualification() (event.Disqualification, bool) {
+	if tt.disqualification == nil {
+		return event.Disqualification{}, false
 	}
 
-	return 0, tt.state
+	return *tt.disqualification, true
 }
@@ -25,10 +25,57 @@ func Test_totalTimeReporter(t *testing.T) {
 		})
 
 		duration, finalState := tt.GetTotalTime()
-		assert.Equal(t, finished, finalState)
+		assert.Equal(t, StatusFinished, finalState)
 		assert.Equal(t, time.Minute*37+time.Second*22+time.Millisecond*342, duration)
 	})
 
+	t.Run("when competitor started not in time", func(t *testing.T) {
+		t.Parallel()
+
+		finishTime := time.Date(0, time.January, 1, 10, 40, 0, 0, time.UTC)
+
+		cases := []struct {
+			name          string
+			scheduled     string
+			started       time.Time
+			expectedTotal time.Duration
+		}{
+			{
+				name:          "early start is counted from scheduled start",
+				scheduled:     "10:10:00.000",
+				started:       time.Date(0, time.January, 1, 10, 9, 30, 0, time.UTC),
+				expectedTotal: time.Minute * 30,
+			},
+			{
+				name:          "late start is counted from scheduled start",
+				scheduled:     "10:10:00.000",
+				started:       time.Date(0, time.January, 1, 10, 10, 30, 0, time.UTC),
+				expectedTotal: time.Minute * 30,
+			},
+			{
+				name:          "finish before scheduled start gives zero time",
+				scheduled:     "10:50:00.000",
+				started:       time.Date(0, time.January, 1, 10, 10, 30, 0, time.UTC),
+				expectedTotal: 0,
+			},
+		}
+
+		for _, c := range cases {
+			t.Run(c.name, func(t *testing.T) {
+				tt := newTotalTimeReporter()
+
+				tt.NotifyWithEvent(event.Event{ID: event.StartTimeAssignment, Extra: c.scheduled})
+
+				tt.NotifyWithEvent(event.Event{Time: c.started, ID: event.CompetitorStarted})
+				tt.NotifyWithEvent(event.Event{Time: finishTime, ID: event.CompetitorFinished})
+
+				duration, finalState := tt.GetTotalTime()
+				assert.Equal(t, StatusFinished, finalState)
+				assert.Equal(t, c.expectedTotal, duration)
+			})
+		}
+	})
+
 	t.Run("when competitor is disqualified", func(t *testing.T) {
 		t.Parallel()
 
@@ -47,8 +94,11 @@ func Test_totalTimeReporter(t *testing.T) {
 			})
 
 			duration, finalState := tt.GetTotalTime()
-			assert.Equal(t, notStarted, finalState)
+			assert.Equal(t, StatusDSQ, finalState)
 			assert.Equal(t, time.Duration(0), duration)
+
+			_, ok := tt.Disqualification()
+			assert.True(t, ok)
 		})
 
 		t.Run("disqualified before competitor start", func(t *testing.T) {
@@ -58,7 +108,7 @@ func Test_totalTimeReporter(t *testing.T) {
 				ID:    event.StartTimeAssignment,
 				Extra: "10:03:30.000",
 			})
-			tt.NotifyWithEvent(event.Event{ID: event.CompetitorDisqualified})
+			tt.NotifyWithEvent(event.Event{ID: event.CompetitorDisqualified, Extra: "lateStart late start by 95.000 seconds"})
 			tt.NotifyWithEvent(event.Event{ID: event.CompetitorStarted})
 			tt.NotifyWithEvent(event.Event{
 				Time: time.Date(0, time.January, 1, 10, 40, 52, 342_000_000, time.UTC),
@@ -66,8 +116,12 @@ func Test_totalTimeReporter(t *testing.T) {
 			})
 
 			duration, finalState := tt.GetTotalTime()
-			assert.Equal(t, notStarted, finalState)
+			assert.Equal(t, StatusDSQ, finalState)
 			assert.Equal(t, time.Duration(0), duration)
+
+			disqualification, ok := tt.Disqualification()
+			assert.True(t, ok)
+			assert.Equal(t, event.Disqualification{Reason: event.ReasonLateStart, Text: "late start by 95.000 seconds"}, disqualification)
 		})
 	})
 
@@ -88,7 +142,28 @@ func Test_totalTimeReporter(t *testing.T) {
 		})
 
 		duration, finalState := tt.GetTotalTime()
-		assert.Equal(t, notFinished, finalState)
+		assert.Equal(t, StatusDNF, finalState)
+		assert.Equal(t, time.Duration(0), duration)
+	})
+
+	t.Run("when competitor is lapped", func(t *testing.T) {
+		t.Parallel()
+
+		tt := newTotalTimeReporter()
+
+		tt.NotifyWithEvent(event.Event{
+			ID:    event.StartTimeAssignment,
+			Extra: "10:03:30.000",
+		})
+		tt.NotifyWithEvent(event.Event{ID: event.CompetitorStarted})
+		tt.NotifyWithEvent(event.Event{ID: event.CompetitorLapped})
+		tt.NotifyWithEvent(event.Event{
+			Time: time.Date(0, time.January, 1, 10, 40, 52, 342_000_000, time.UTC),
+			ID:   event.CompetitorFinished,
+		})
+
+		duration, finalState := tt.GetTotalTime()
+		assert.Equal(t, StatusLAP, finalState)
 		assert.Equal(t, time.Duration(0), duration)
 	})
 
@@ -105,7 +180,7 @@ func Test_totalTimeReporter(t *testing.T) {
 			tt.NotifyWithEvent(event.Event{ID: event.CompetitorStarted})
 
 			duration, finalState := tt.GetTotalTime()
-			assert.Equal(t, notFinished, finalState)
+			assert.Equal(t, StatusDNF, finalState)
 			assert.Equal(t, time.Duration(0), duration)
 		})
 
@@ -118,8 +193,65 @@ func Test_totalTimeReporter(t *testing.T) {
 			})
 
 			duration, finalState := tt.GetTotalTime()
-			assert.Equal(t, notStarted, finalState)
+			assert.Equal(t, StatusDNS, finalState)
 			assert.Equal(t, time.Duration(0), duration)
 		})
 	})
+
+	t.Run("with jury corrections", func(t *testing.T) {
+		t.Parallel()
+
+		finishTime := time.Date(0, time.January, 1, 10, 40, 0, 0, time.UTC)
+
+		t.Run("time penalty and bonus", func(t *testing.T) {
+			tt := newTotalTimeReporter()
+
+			tt.NotifyWithEvent(event.Event{ID: event.StartTimeAssignment, Extra: "10:10:00.000"})
+			tt.NotifyWithEvent(event.Event{ID: event.CompetitorStarted})
+			tt.NotifyWithEvent(event.Event{ID: event.JuryTimePenalty, Extra: "00:02:00 missed penalty lap"})
+			tt.NotifyWithEvent(event.Event{Time: finishTime, ID: event.CompetitorFinished})
+			tt.NotifyWithEvent(event.Event{ID: event.JuryTimeBonus, Extra: "00:00:30.500"})
+
+			duration, finalState := tt.GetTotalTime()
+			assert.Equal(t, StatusFinished, finalState)
+			assert.Equal(t, time.Minute*31+time.Second*29+time.Millisecond*500, duration)
+			assert.Len(t, tt.corrections, 2)
+		})
+
+		t.Run("disqualification and reinstatement", func(t *testing.T) {
+			tt := newTotalTimeReporter()
+
+			tt.NotifyWithEvent(event.Event{ID: event.StartTimeAssignment, Extra: "10:10:00.000"})
+			tt.NotifyWithEvent(event.Event{ID: event.CompetitorDisqualified})
+			tt.NotifyWithEvent(event.Event{ID: event.CompetitorStarted})
+			tt.NotifyWithEvent(event.Event{ID: event.JuryReinstatement})
+			tt.NotifyWithEvent(event.Event{Time: finishTime, ID: event.CompetitorFinished})
+
+			duration, finalState := tt.GetTotalTime()
+			assert.Equal(t, StatusFinished, finalState)
+			assert.Equal(t, time.Minute*30, duration)
+
+			tt.NotifyWithEvent(event.Event{ID: event.JuryDisqualification, Extra: "unsportsmanlike conduct"})
+			tt.NotifyWithEvent(event.Event{ID: event.CompetitorDisqualified, Extra: "unsportsmanlike conduct"})
+
+			_, finalState = tt.GetTotalTime()
+			assert.Equal(t, StatusDSQ, finalState)
+			assert.Len(t, tt.corrections, 2)
+		})
+
+		t.Run("reinstatement after the last lap", func(t *testing.T) {
+			tt := newTotalTimeReporter()
+
+			tt.NotifyWithEvent(event.Event{ID: event.StartTimeAssignment, Extra: "10:10:00.000"})
+			tt.NotifyWithEvent(event.Event{ID: event.CompetitorStarted})
+			tt.NotifyWithEvent(event.Event{ID: event.CompetitorDisqualified})
+			tt.NotifyWithEvent(event.Event{Time: finishTime, ID: event.CompetitorEndedMainLap})
+			tt.NotifyWithEvent(event.Event{Time: finishTime.Add(time.Hour), ID: event.JuryReinstatement})
+			tt.NotifyWithEvent(event.Event{Time: finishTime.Add(time.Hour), ID: event.CompetitorFinished})
+
+			duration, finalState := tt.GetTotalTime()
+			assert.Equal(t, StatusFinished, finalState)
+			assert.Equal(t, time.Minute*30, duration)
+		})
+	})
 }
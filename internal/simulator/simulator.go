@@ -0,0 +1,336 @@
+// simulator contains generator of synthetic, but valid events of biathlon competition.
+package simulator
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/config"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event/parser"
+)
+
+// ErrBadParams is returned if Params have invalid values.
+var ErrBadParams = errors.New("bad simulation params")
+
+// Params are statistical parameters of simulated race.
+type Params struct {
+	// Competitors - amount of competitors, they get ids from 1 to Competitors.
+	Competitors int
+	// Seed of random number generator, the same seed produces the same events.
+	Seed uint64
+	// SpeedMean - mean ski speed of competitors in m/s.
+	SpeedMean float64
+	// SpeedStdDev - standard deviation of ski speed of competitors in m/s.
+	SpeedStdDev float64
+	// HitProbability - probability to hit a target with single shot.
+	HitProbability float64
+	// DNSRate - probability, that the competitor does not start.
+	DNSRate float64
+	// DNFRate - probability, that the competitor starts, but cannot continue.
+	DNFRate float64
+	// LateStartRate - probability, that the competitor starts after his/her start interval.
+	LateStartRate float64
+	// MaxStartLateness - maximum delay of start after assigned start time for competitors,
+	// who start in time. It is limited by start interval of config.
+	MaxStartLateness time.Duration
+	// Shots - if true, event 12 is generated for each shot.
+	Shots bool
+}
+
+// DefaultParams returns Params of a typical sprint race.
+func DefaultParams() Params {
+	return Params{
+		Competitors:      30,
+		Seed:             1,
+		SpeedMean:        6,
+		SpeedStdDev:      0.4,
+		HitProbability:   0.85,
+		DNSRate:          0.03,
+		DNFRate:          0.03,
+		LateStartRate:    0.02,
+		MaxStartLateness: 2 * time.Second,
+	}
+}
+
+// Validate checks values of Params.
+func (p Params) Validate() error {
+	errs := make([]error, 0)
+
+	if p.Competitors <= 0 {
+		errs = append(errs, fmt.Errorf("%w: competitors must be positive, got: %d", ErrBadParams, p.Competitors))
+	}
+
+	if p.SpeedMean <= 0 || p.SpeedStdDev < 0 {
+		errs = append(errs, fmt.Errorf("%w: speed mean must be positive and standard deviation must not be negative, got: %g, %g",
+			ErrBadParams, p.SpeedMean, p.SpeedStdDev))
+	}
+
+	probabilities := map[string]float64{
+		"hit probability": p.HitProbability,
+		"DNS rate":        p.DNSRate,
+		"DNF rate":        p.DNFRate,
+		"late start rate": p.LateStartRate,
+	}
+
+	for _, name := range []string{"hit probability", "DNS rate", "DNF rate", "late start rate"} {
+		if probabilities[name] < 0 || probabilities[name] > 1 {
+			errs = append(errs, fmt.Errorf("%w: %s must be from 0 to 1, got: %g", ErrBadParams, name, probabilities[name]))
+		}
+	}
+
+	if p.MaxStartLateness < 0 {
+		errs = append(errs, fmt.Errorf("%w: max start lateness must not be negative, got: %s", ErrBadParams, p.MaxStartLateness))
+	}
+
+	return errors.Join(errs...)
+}
+
+// Timings of race, that are not configured by Params.
+const (
+	registrationPeriod = 30 * time.Minute
+	drawBeforeStart    = 5 * time.Minute
+	onStartLine        = 15 * time.Second
+	firstShotDelay     = 20 * time.Second
+	shotInterval       = 3 * time.Second
+	leaveFiringRange   = 5 * time.Second
+	// shotIntervalJitter - part of shotInterval, that is randomly added to it.
+	shotIntervalJitter = 0.2
+	// afterFiringRangePart - part of lap after firing range.
+	afterFiringRangePart = 0.1
+	// dnfLapPart - part of lap, after which the competitor cannot continue.
+	dnfLapPart = 0.5
+	// lapSpeedJitter - relative standard deviation of competitor's speed on each lap.
+	lapSpeedJitter = 0.03
+	// minSpeed limits ski speed of slow competitors, so they do not ski forever.
+	minSpeed = 1.0
+)
+
+var dnfComments = []string{"Lost in the forest", "Broken ski", "Injury", "Exhausted"}
+
+// Generate returns chronologically ordered incoming events of simulated race with given config.
+// Events pass parser.TargetsChecker and produce competitors with all final statuses: finished,
+// not started, not finished and disqualified for late start.
+func Generate(conf config.BiathlonCompetition, params Params) ([]event.Event, error) {
+	competitors, err := GenerateByCompetitor(conf, params)
+	if err != nil {
+		return nil, err
+	}
+
+	events := slices.Concat(competitors...)
+
+	// events of each competitor are ordered, so stable sort keeps their order for equal times.
+	slices.SortStableFunc(events, func(a, b event.Event) int {
+		return a.Time.Compare(b.Time)
+	})
+
+	return events, nil
+}
+
+// GenerateByCompetitor returns the same events as Generate, but grouped by competitor:
+// i-th slice contains chronologically ordered events of competitor with id i+1.
+// It is used to send events of different competitors from different producers.
+func GenerateByCompetitor(conf config.BiathlonCompetition, params Params) ([][]event.Event, error) {
+	err := params.Validate()
+	if err != nil {
+		return nil, err
+	}
+
+	start, err := time.Parse(time.TimeOnly, conf.Start)
+	if err != nil {
+		return nil, fmt.Errorf("bad start of config: %w", err)
+	}
+
+	startDelta, err := parser.ParseDuration(conf.StartDelta)
+	if err != nil {
+		return nil, fmt.Errorf("bad start delta of config: %w", err)
+	}
+
+	s := &simulation{
+		params:         params,
+		rand:           rand.New(rand.NewPCG(params.Seed, params.Seed)),
+		lapLens:        conf.LapLengths(),
+		penaltyLen:     conf.PenaltyLen,
+		targets:        conf.TargetsPerFiringLine(),
+		firingLineLaps: firingLineLaps(conf),
+		start:          start,
+		startDelta:     startDelta,
+	}
+
+	competitors := make([][]event.Event, params.Competitors)
+	for i := range competitors {
+		competitors[i] = s.competitor(i)
+	}
+
+	return competitors, nil
+}
+
+// Write writes events in the format of events file.
+func Write(w io.Writer, events []event.Event) error {
+	for _, e := range events {
+		_, err := fmt.Fprintln(w, e.Line())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// firingLineLaps returns number of lap, which ends with firing line, for each firing line.
+// By default firing lines are placed at the end of first laps.
+func firingLineLaps(conf config.BiathlonCompetition) []uint32 {
+	if len(conf.FiringLineLaps) != 0 {
+		return conf.FiringLineLaps
+	}
+
+	laps := make([]uint32, conf.FiringLines)
+	for i := range laps {
+		laps[i] = uint32(i) + 1
+	}
+
+	return laps
+}
+
+type simulation struct {
+	params         Params
+	rand           *rand.Rand
+	lapLens        []uint32
+	penaltyLen     uint32
+	targets        []uint32
+	firingLineLaps []uint32
+	start          time.Time
+	startDelta     time.Duration
+}
+
+// competitor returns events of i-th competitor.
+func (s *simulation) competitor(i int) []event.Event {
+	competitorID := strconv.Itoa(i + 1)
+	startTime := s.start.Add(time.Duration(i) * s.startDelta)
+
+	events := make([]event.Event, 0)
+	add := func(at time.Time, id event.EventID, extra string) {
+		events = append(events, event.Event{
+			Time:         at.Truncate(time.Millisecond),
+			ID:           id,
+			CompetitorID: competitorID,
+			Extra:        extra,
+		})
+	}
+
+	add(s.start.Add(-registrationPeriod-drawBeforeStart).Add(s.randDuration(registrationPeriod)), event.CompetitorRegistration, "")
+	add(startTime.Add(-drawBeforeStart), event.StartTimeAssignment, startTime.Format(event.TimeFormat))
+
+	if s.happens(s.params.DNSRate) {
+		return events
+	}
+
+	add(startTime.Add(-onStartLine).Add(s.randDuration(onStartLine)), event.CompetitorOnStartine, "")
+
+	lateness := s.randDuration(min(s.params.MaxStartLateness, s.startDelta))
+	if s.happens(s.params.LateStartRate) {
+		lateness = s.startDelta + time.Millisecond + s.randDuration(s.startDelta)
+	}
+
+	now := startTime.Add(lateness)
+	add(now, event.CompetitorStarted, "")
+
+	speed := max(minSpeed, s.params.SpeedMean+s.rand.NormFloat64()*s.params.SpeedStdDev)
+	dnfLap := -1
+
+	if s.happens(s.params.DNFRate) {
+		dnfLap = s.rand.IntN(len(s.lapLens))
+	}
+
+	for lap, lapLen := range s.lapLens {
+		lapSpeed := max(minSpeed, speed*(1+s.rand.NormFloat64()*lapSpeedJitter))
+		lapTime := skiTime(float64(lapLen), lapSpeed)
+
+		if lap == dnfLap {
+			now = now.Add(time.Duration(float64(lapTime) * dnfLapPart))
+			add(now, event.CompetitorCannotContinue, dnfComments[s.rand.IntN(len(dnfComments))])
+
+			return events
+		}
+
+		firingLine := slices.Index(s.firingLineLaps, uint32(lap)+1)
+		if firingLine < 0 {
+			now = now.Add(lapTime)
+			add(now, event.CompetitorEndedMainLap, "")
+
+			continue
+		}
+
+		now = now.Add(time.Duration(float64(lapTime) * (1 - afterFiringRangePart)))
+		now = s.shoot(now, firingLine, lapSpeed, add)
+		now = now.Add(time.Duration(float64(lapTime) * afterFiringRangePart))
+		add(now, event.CompetitorEndedMainLap, "")
+	}
+
+	return events
+}
+
+// shoot adds events of visiting firing line and running penalty laps, returns time after them.
+func (s *simulation) shoot(now time.Time, firingLine int, speed float64, add func(time.Time, event.EventID, string)) time.Time {
+	add(now, event.CompetitorOnFiringRange, strconv.Itoa(firingLine+1))
+	now = now.Add(firstShotDelay)
+
+	misses := 0
+
+	for target := range int(s.targets[firingLine]) {
+		if target > 0 {
+			now = now.Add(shotInterval + s.randDuration(time.Duration(float64(shotInterval)*shotIntervalJitter)))
+		}
+
+		hit := s.happens(s.params.HitProbability)
+		if s.params.Shots {
+			result := "miss"
+			if hit {
+				result = "hit"
+			}
+
+			add(now, event.ShotFired, strconv.Itoa(target+1)+" "+result)
+		}
+
+		if hit {
+			add(now, event.TargetHit, strconv.Itoa(target+1))
+		} else {
+			misses += 1
+		}
+	}
+
+	now = now.Add(leaveFiringRange)
+	add(now, event.CompetitorLeftFiringRange, "")
+
+	if misses == 0 {
+		return now
+	}
+
+	add(now, event.CompetitorEnterPenaltyLaps, "")
+	now = now.Add(skiTime(float64(misses)*float64(s.penaltyLen), speed))
+	add(now, event.CompetitorLeftPenaltyLaps, "")
+
+	return now
+}
+
+func (s *simulation) happens(probability float64) bool {
+	return s.rand.Float64() < probability
+}
+
+// randDuration returns random duration from 0 to d.
+func (s *simulation) randDuration(d time.Duration) time.Duration {
+	if d <= 0 {
+		return 0
+	}
+
+	return time.Duration(s.rand.Int64N(int64(d)))
+}
+
+func skiTime(distance float64, speed float64) time.Duration {
+	return time.Duration(distance / speed * float64(time.Second))
+}
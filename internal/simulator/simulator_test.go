@@ -0,0 +1,157 @@
+package simulator
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/competition"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/config"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event/parser"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/report"
+	"github.com/stretchr/testify/assert"
+)
+
+var testConfig = config.BiathlonCompetition{
+	Laps:           3,
+	LapLen:         3300,
+	PenaltyLen:     150,
+	FiringLines:    2,
+	FiringLineLaps: []uint32{1, 3},
+	Start:          "10:00:00",
+	StartDelta:     "00:00:30",
+}
+
+// statuses handles events and returns amount of competitors with each final status.
+func statuses(t *testing.T, conf config.BiathlonCompetition, events []event.Event) map[report.Status]int {
+	t.Helper()
+
+	reporter := report.NewReporter(conf)
+	biathlon, err := competition.NewBiathlon(conf, reporter)
+	assert.Nil(t, err)
+
+	targetsChecker := parser.NewTargetsChecker(conf)
+
+	for i, e := range events {
+		if i > 0 {
+			assert.False(t, e.Time.Before(events[i-1].Time), "events are not ordered: %s", e.Line())
+		}
+
+		assert.Nil(t, targetsChecker.Check(e))
+		biathlon.HandleEvent(e)
+	}
+
+	assert.Nil(t, biathlon.Err())
+
+	res := make(map[report.Status]int)
+	for _, line := range strings.Split(strings.TrimSpace(reporter.MakeReport().String()), "\n") {
+		status, _, _ := strings.Cut(strings.TrimPrefix(line, "["), "]")
+		if strings.Contains(status, ":") {
+			status = string(report.StatusFinished)
+		}
+
+		res[report.Status(status)] += 1
+	}
+
+	return res
+}
+
+func Test_Generate(t *testing.T) {
+	t.Run("the same seed produces the same events", func(t *testing.T) {
+		params := DefaultParams()
+		params.Shots = true
+
+		first, err := Generate(testConfig, params)
+		assert.Nil(t, err)
+
+		second, err := Generate(testConfig, params)
+		assert.Nil(t, err)
+		assert.Equal(t, first, second)
+
+		params.Seed += 1
+
+		third, err := Generate(testConfig, params)
+		assert.Nil(t, err)
+		assert.NotEqual(t, first, third)
+	})
+
+	t.Run("events are valid and parsed back", func(t *testing.T) {
+		params := DefaultParams()
+		params.Competitors = 200
+		params.Shots = true
+
+		events, err := Generate(testConfig, params)
+		assert.Nil(t, err)
+
+		res := statuses(t, testConfig, events)
+		assert.Equal(t, params.Competitors, res[report.StatusFinished]+res[report.StatusDNS]+res[report.StatusDNF]+res[report.StatusDSQ])
+		assert.Positive(t, res[report.StatusFinished])
+		assert.Positive(t, res[report.StatusDNS])
+		assert.Positive(t, res[report.StatusDNF])
+		assert.Positive(t, res[report.StatusDSQ])
+
+		buf := bytes.Buffer{}
+		assert.Nil(t, Write(&buf, events))
+
+		lines, retErrFunc := parser.Lines(&buf)
+		parsed := make([]event.Event, 0, len(events))
+
+		for e, err := range parser.ParsedLines(lines) {
+			assert.Nil(t, err)
+			parsed = append(parsed, e)
+		}
+
+		assert.Nil(t, retErrFunc())
+		assert.Equal(t, events, parsed)
+	})
+
+	t.Run("perfect race", func(t *testing.T) {
+		params := DefaultParams()
+		params.HitProbability = 1
+		params.DNSRate = 0
+		params.DNFRate = 0
+		params.LateStartRate = 0
+
+		events, err := Generate(testConfig, params)
+		assert.Nil(t, err)
+
+		for _, e := range events {
+			assert.NotEqual(t, event.CompetitorEnterPenaltyLaps, e.ID)
+		}
+
+		assert.Equal(t, map[report.Status]int{report.StatusFinished: params.Competitors}, statuses(t, testConfig, events))
+	})
+
+	t.Run("nobody starts", func(t *testing.T) {
+		params := DefaultParams()
+		params.DNSRate = 1
+
+		events, err := Generate(testConfig, params)
+		assert.Nil(t, err)
+		assert.Len(t, events, 2*params.Competitors)
+		assert.Equal(t, map[report.Status]int{report.StatusDNS: params.Competitors}, statuses(t, testConfig, events))
+	})
+
+	t.Run("everybody starts late", func(t *testing.T) {
+		params := DefaultParams()
+		params.LateStartRate = 1
+		params.DNSRate = 0
+		params.DNFRate = 0
+
+		events, err := Generate(testConfig, params)
+		assert.Nil(t, err)
+		assert.Equal(t, map[report.Status]int{report.StatusDSQ: params.Competitors}, statuses(t, testConfig, events))
+	})
+
+	t.Run("bad params", func(t *testing.T) {
+		params := DefaultParams()
+		params.Competitors = 0
+		params.HitProbability = 1.5
+		params.SpeedMean = -1
+
+		_, err := Generate(testConfig, params)
+		assert.ErrorIs(t, err, ErrBadParams)
+		assert.Len(t, err.(interface{ Unwrap() []error }).Unwrap(), 3)
+	})
+}
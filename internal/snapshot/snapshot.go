@@ -0,0 +1,102 @@
+// snapshot contains types and functions need to save state of events handling to file
+// and restore it, so handling can be continued from the last saved event.
+package snapshot
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/competition"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/config"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event/parser"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/report"
+)
+
+// ErrConfigMismatch is returned by Snapshot.Check if the snapshot is taken with another config.
+var ErrConfigMismatch = errors.New("snapshot is taken with different config")
+
+// Snapshot is state of events handling after first Offset events.
+type Snapshot struct {
+	// Offset - amount of handled events. Handling is continued from the event with this index.
+	Offset int `json:"offset"`
+	// Config of the competition, state is valid only for the same config.
+	Config config.BiathlonCompetition `json:"config"`
+	// Competition - state of competition.Biathlon.
+	Competition competition.BiathlonSnapshot `json:"competition"`
+	// Report - state of report.Reporter.
+	Report report.ReporterSnapshot `json:"report"`
+	// Targets - state of parser.TargetsChecker.
+	Targets parser.TargetsCheckerSnapshot `json:"targets"`
+	// Journal - sequence number of the last record in journal, 0 if journal is not used.
+	Journal uint64 `json:"journal,omitempty"`
+}
+
+// Check returns ErrConfigMismatch if the snapshot is taken with config different from the given one.
+func (s Snapshot) Check(conf config.BiathlonCompetition) error {
+	expected, err := json.Marshal(conf)
+	if err != nil {
+		return err
+	}
+
+	actual, err := json.Marshal(s.Config)
+	if err != nil {
+		return err
+	}
+
+	if !bytes.Equal(expected, actual) {
+		return ErrConfigMismatch
+	}
+
+	return nil
+}
+
+// Read snapshot from file with given path.
+func Read(path string) (Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Snapshot{}, err
+	}
+
+	snapshot := Snapshot{}
+
+	err = json.Unmarshal(data, &snapshot)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("failed to parse snapshot file: %s, err: %w", path, err)
+	}
+
+	return snapshot, nil
+}
+
+// Write snapshot to file with given path. Snapshot is written to temporary file first,
+// which then replaces the file with given path, so the file always contains complete snapshot.
+func Write(path string, snapshot Snapshot) error {
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	_, err = tmp.Write(data)
+	if err == nil {
+		err = tmp.Sync()
+	}
+
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		return fmt.Errorf("write temporary file: %w", err)
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
@@ -0,0 +1,152 @@
+package snapshot
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/competition"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/config"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/event/parser"
+	"github.com/AleksandrMatsko/yadro-biathlon/internal/report"
+	"github.com/stretchr/testify/assert"
+)
+
+const testEvents = `[09:31:49.285] 1 1
+[09:32:17.531] 1 2
+[09:33:00.000] 1 3
+[09:55:00.000] 2 1 10:00:00.000
+[09:56:30.000] 2 2 10:01:30.000
+[09:58:00.000] 2 3 10:03:00.000
+[10:00:01.744] 4 1
+[10:01:31.503] 4 2
+[10:08:49.289] 5 1 1
+[10:08:50.000] 12 1 1 hit
+[10:08:50.884] 6 1 1
+[10:08:51.400] 12 1 2 miss
+[10:08:55.658] 7 1
+[10:09:03.232] 8 1
+[10:10:00.000] 9 1
+[10:10:22.273] 5 2 1
+[10:10:23.804] 6 2 1
+[10:10:25.036] 6 2 2
+[10:10:30.000] 7 2
+[10:12:00.000] 10 2
+[10:20:00.000] 10 1
+[10:25:00.000] 11 2 Lost in the forest
+[10:29:00.000] 5 1 2
+[10:29:10.000] 6 1 1
+[10:29:20.000] 7 1
+[10:31:00.000] 10 1
+[10:32:00.000] 13 1 00:00:30 false start
+`
+
+func testConfig() config.BiathlonCompetition {
+	return config.BiathlonCompetition{
+		Laps:        2,
+		LapLen:      3500,
+		PenaltyLen:  150,
+		FiringLines: 2,
+		Targets:     2,
+		Start:       "10:00:00.000",
+		StartDelta:  "00:01:30",
+	}
+}
+
+type pipeline struct {
+	biathlon       *competition.Biathlon
+	reporter       *report.Reporter
+	targetsChecker *parser.TargetsChecker
+}
+
+func newPipeline(t *testing.T, conf config.BiathlonCompetition) pipeline {
+	reporter := report.NewReporter(conf)
+
+	biathlon, err := competition.NewBiathlon(conf, reporter)
+	assert.Nil(t, err)
+
+	return pipeline{
+		biathlon:       biathlon,
+		reporter:       reporter,
+		targetsChecker: parser.NewTargetsChecker(conf),
+	}
+}
+
+func (p pipeline) handle(t *testing.T, events []event.Event) {
+	for _, e := range events {
+		assert.Nil(t, p.targetsChecker.Check(e))
+		p.biathlon.HandleEvent(e)
+	}
+}
+
+func (p pipeline) document(t *testing.T, conf config.BiathlonCompetition) string {
+	buf := bytes.Buffer{}
+	assert.Nil(t, report.NewDocument(p.reporter.MakeReport(), conf).Write(&buf, report.FormatJSON))
+
+	return buf.String()
+}
+
+func Test_Snapshot_Restore(t *testing.T) {
+	conf := testConfig()
+
+	events := make([]event.Event, 0)
+	for line := range strings.Lines(testEvents) {
+		e, err := parser.ParseSingleLine(strings.TrimSpace(line))
+		assert.Nil(t, err)
+
+		events = append(events, e)
+	}
+
+	uninterrupted := newPipeline(t, conf)
+	uninterrupted.handle(t, events)
+
+	expected := uninterrupted.document(t, conf)
+
+	for offset := range len(events) + 1 {
+		path := filepath.Join(t.TempDir(), "snapshot.json")
+
+		interrupted := newPipeline(t, conf)
+		interrupted.handle(t, events[:offset])
+
+		err := Write(path, Snapshot{
+			Offset:      offset,
+			Config:      conf,
+			Competition: interrupted.biathlon.Snapshot(),
+			Report:      interrupted.reporter.Snapshot(),
+			Targets:     interrupted.targetsChecker.Snapshot(),
+		})
+		assert.Nil(t, err)
+
+		saved, err := Read(path)
+		assert.Nil(t, err)
+		assert.Nil(t, saved.Check(conf))
+		assert.Equal(t, offset, saved.Offset)
+
+		restored := newPipeline(t, conf)
+		assert.Nil(t, restored.biathlon.Restore(saved.Competition))
+		restored.reporter.Restore(saved.Report)
+		restored.targetsChecker.Restore(saved.Targets)
+
+		restored.handle(t, events[saved.Offset:])
+
+		assert.Equal(t, expected, restored.document(t, conf), "restored after %d events", offset)
+	}
+}
+
+func Test_Snapshot_Check(t *testing.T) {
+	conf := testConfig()
+
+	assert.Nil(t, Snapshot{Config: conf}.Check(conf))
+
+	otherConf := conf
+	otherConf.Laps = 3
+
+	assert.ErrorIs(t, Snapshot{Config: conf}.Check(otherConf), ErrConfigMismatch)
+}
+
+func Test_Read(t *testing.T) {
+	_, err := Read(filepath.Join(t.TempDir(), "not-exist.json"))
+	assert.NotNil(t, err)
+}